@@ -6,11 +6,9 @@ require (
 	github.com/aws/aws-lambda-go v1.47.0
 	github.com/golang-jwt/jwt/v5 v5.3.0
 	github.com/gorilla/mux v1.8.1
+	github.com/sahilm/fuzzy v0.1.1
 	golang.org/x/crypto v0.46.0
 	golang.org/x/term v0.38.0
 )
 
-require (
-	github.com/sahilm/fuzzy v0.1.1 // indirect
-	golang.org/x/sys v0.39.0 // indirect
-)
+require golang.org/x/sys v0.39.0 // indirect
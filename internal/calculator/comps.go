@@ -0,0 +1,162 @@
+package calculator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// minCompsPeers is the fewest peers reporting a multiple before it is used
+const minCompsPeers = 3
+
+// Multiples used by the comparables valuation
+const (
+	MultiplePE          = "pe"
+	MultipleEVToEBITDA  = "ev_ebitda"
+	MultipleEVToSales   = "ev_sales"
+	MultiplePriceToBook = "pb"
+)
+
+// CalculateComps values a company at its peers' multiples. Each multiple's peer
+// 25th percentile, median and 75th percentile are applied to the company's own
+// earnings, EBITDA, revenue and book value; the fair value is the average of the
+// median-implied prices and the range averages the quartile-implied prices.
+func CalculateComps(companyData *finance.CompanyData, peers []finance.PeerMultiples, peerSource string) (*finance.ValuationResult, error) {
+	financials := companyData.LatestFinancials
+	if financials == nil {
+		return nil, fmt.Errorf("no financial data available for comparables valuation")
+	}
+	if companyData.SharesOutstanding <= 0 {
+		return nil, fmt.Errorf("shares outstanding not available")
+	}
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("no peers available for comparables valuation")
+	}
+
+	shares := companyData.SharesOutstanding * 1_000_000 // Shares in millions
+	bridge := buildEquityBridge(financials)
+	ebitda := financials.OperatingIncome + financials.DepreciationAmortization
+
+	// Enterprise value multiples convert to a share price through the equity bridge
+	evToPrice := func(ev float64) float64 {
+		return (ev - bridge.NetDebt - bridge.MinorityInterest - bridge.PreferredEquity) / shares
+	}
+
+	comps := &finance.CompsResult{
+		PeerSource: peerSource,
+		Peers:      peers,
+	}
+
+	specs := []struct {
+		name    string
+		peer    func(finance.PeerMultiples) float64
+		subject float64
+		price   func(multiple float64) float64
+	}{
+		{
+			name:    MultiplePE,
+			peer:    func(p finance.PeerMultiples) float64 { return p.PERatio },
+			subject: financials.NetIncome / shares,
+			price: func(multiple float64) float64 {
+				fairValue, _ := CalculateFairValueSimple(companyData, multiple)
+				return fairValue
+			},
+		},
+		{
+			name:    MultipleEVToEBITDA,
+			peer:    func(p finance.PeerMultiples) float64 { return p.EVToEBITDA },
+			subject: ebitda,
+			price:   func(multiple float64) float64 { return evToPrice(multiple * ebitda) },
+		},
+		{
+			name:    MultipleEVToSales,
+			peer:    func(p finance.PeerMultiples) float64 { return p.EVToSales },
+			subject: financials.Revenue,
+			price:   func(multiple float64) float64 { return evToPrice(multiple * financials.Revenue) },
+		},
+		{
+			name:    MultiplePriceToBook,
+			peer:    func(p finance.PeerMultiples) float64 { return p.PriceToBook },
+			subject: financials.ShareholdersEquity / shares,
+			price:   func(multiple float64) float64 { return multiple * financials.ShareholdersEquity / shares },
+		},
+	}
+
+	var lows, mids, highs []float64
+	for _, spec := range specs {
+		multiple := finance.CompsMultiple{
+			Name:          spec.name,
+			SubjectMetric: spec.subject,
+		}
+
+		// Negative multiples (losses, negative book value) are not meaningful
+		var values []float64
+		for _, peer := range peers {
+			if v := spec.peer(peer); v > 0 {
+				values = append(values, v)
+			}
+		}
+		multiple.PeerCount = len(values)
+
+		switch {
+		case len(values) < minCompsPeers:
+			multiple.Note = fmt.Sprintf("Only %d peers report this multiple (need %d)", len(values), minCompsPeers)
+		case spec.subject <= 0:
+			multiple.Note = "Company's own metric is not positive"
+		default:
+			sort.Float64s(values)
+			multiple.P25 = percentile(values, 0.25)
+			multiple.Median = percentile(values, 0.50)
+			multiple.P75 = percentile(values, 0.75)
+			multiple.ImpliedLow = spec.price(multiple.P25)
+			multiple.ImpliedMid = spec.price(multiple.Median)
+			multiple.ImpliedHigh = spec.price(multiple.P75)
+			multiple.Available = true
+
+			lows = append(lows, multiple.ImpliedLow)
+			mids = append(mids, multiple.ImpliedMid)
+			highs = append(highs, multiple.ImpliedHigh)
+		}
+
+		comps.Multiples = append(comps.Multiples, multiple)
+	}
+
+	if len(mids) == 0 {
+		return nil, fmt.Errorf("no peer multiple could be applied to this company")
+	}
+
+	fairValue := mean(mids)
+	comps.FairValueLow = mean(lows)
+	comps.FairValueHigh = mean(highs)
+
+	currentPrice := 0.0
+	if companyData.Quote != nil {
+		currentPrice = companyData.Quote.CurrentPrice
+	}
+
+	upsidePercent := 0.0
+	if currentPrice > 0 {
+		upsidePercent = ((fairValue - currentPrice) / currentPrice) * 100
+	}
+
+	return &finance.ValuationResult{
+		FairValuePerShare: fairValue,
+		CurrentPrice:      currentPrice,
+		UpsidePercent:     upsidePercent,
+		Model:             "Comparables",
+		Assumptions:       finance.DCFAssumptions{Source: peerSource},
+		EquityBridge:      bridge,
+		SharesOutstanding: companyData.SharesOutstanding,
+		Comps:             comps,
+	}, nil
+}
+
+// mean returns the arithmetic mean of values
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,110 @@
+package calculator
+
+import (
+	"math"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// maxReasonableRevenueGrowth rejects consensus growth that usually means mismatched periods
+const maxReasonableRevenueGrowth = 0.50
+
+// Recommendation consensus labels by mean score (1 = strong buy ... 5 = strong sell)
+var recommendationLabels = []struct {
+	maxScore float64
+	label    string
+}{
+	{1.5, "strong_buy"},
+	{2.5, "buy"},
+	{3.5, "hold"},
+	{4.5, "sell"},
+	{math.Inf(1), "strong_sell"},
+}
+
+// AttachAnalystConsensus places the analyst view next to a valuation: the price
+// target range with its upside, the rating distribution with a consensus label,
+// consensus revenue growth, and how our fair value compares to the mean target
+func AttachAnalystConsensus(valuation *finance.ValuationResult, companyData *finance.CompanyData) {
+	if valuation == nil || companyData.AnalystConsensus == nil {
+		return
+	}
+
+	// Copy so per-valuation fields don't leak into the shared company data
+	consensus := *companyData.AnalystConsensus
+	consensus.RevenueGrowthRate = consensusRevenueGrowth(companyData)
+
+	if consensus.PriceTarget != nil {
+		target := *consensus.PriceTarget
+		if valuation.CurrentPrice > 0 {
+			target.UpsidePercent = (target.Mean - valuation.CurrentPrice) / valuation.CurrentPrice * 100
+		}
+		consensus.PriceTarget = &target
+
+		if target.Mean > 0 {
+			vsTarget := (valuation.FairValuePerShare - target.Mean) / target.Mean * 100
+			consensus.FairValueVsTargetPercent = &vsTarget
+		}
+	}
+
+	if consensus.Recommendation != nil {
+		trend := *consensus.Recommendation
+		summarizeRecommendations(&trend)
+		consensus.Recommendation = &trend
+	}
+
+	valuation.AnalystConsensus = &consensus
+}
+
+// summarizeRecommendations scores ratings 1 (strong buy) to 5 (strong sell) and labels the mean
+func summarizeRecommendations(trend *finance.RecommendationTrend) {
+	trend.Total = trend.StrongBuy + trend.Buy + trend.Hold + trend.Sell + trend.StrongSell
+	if trend.Total == 0 {
+		return
+	}
+
+	weighted := 1*trend.StrongBuy + 2*trend.Buy + 3*trend.Hold + 4*trend.Sell + 5*trend.StrongSell
+	trend.MeanScore = float64(weighted) / float64(trend.Total)
+	for _, l := range recommendationLabels {
+		if trend.MeanScore < l.maxScore {
+			trend.Consensus = l.label
+			break
+		}
+	}
+}
+
+// consensusRevenueGrowth annualizes growth from the latest annual revenue to the
+// furthest consensus estimate within maxEstimateHorizon years
+func consensusRevenueGrowth(data *finance.CompanyData) *float64 {
+	if data.AnalystConsensus == nil || len(data.AnalystConsensus.RevenueEstimates) == 0 {
+		return nil
+	}
+
+	baseRevenue, baseYear := 0.0, 0
+	if data.HistoricalData != nil && len(data.HistoricalData.AnnualStatements) > 0 {
+		latest := data.HistoricalData.AnnualStatements[len(data.HistoricalData.AnnualStatements)-1]
+		baseRevenue, baseYear = latest.Revenue, latest.FiscalYear
+	} else if data.LatestFinancials != nil {
+		baseRevenue, baseYear = data.LatestFinancials.Revenue, data.LatestFinancials.FiscalYear
+	}
+	if baseRevenue <= 0 {
+		return nil
+	}
+
+	var target *finance.RevenueEstimate
+	for i := range data.AnalystConsensus.RevenueEstimates {
+		estimate := &data.AnalystConsensus.RevenueEstimates[i]
+		if estimate.FiscalYear > baseYear && estimate.FiscalYear <= baseYear+maxEstimateHorizon && estimate.RevenueAvg > 0 {
+			target = estimate
+		}
+	}
+	if target == nil {
+		return nil
+	}
+
+	years := target.FiscalYear - baseYear
+	growth := math.Pow(target.RevenueAvg/baseRevenue, 1/float64(years)) - 1
+	if math.Abs(growth) > maxReasonableRevenueGrowth {
+		return nil
+	}
+	return &growth
+}
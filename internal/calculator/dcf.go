@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/sshetty/finEdSkywalker/internal/config"
 	"github.com/sshetty/finEdSkywalker/internal/finance"
 )
 
@@ -14,8 +15,38 @@ type DCFInput struct {
 	FCFMargin          *float64 // Optional
 	DiscountRate       *float64 // Optional (WACC or required return)
 	TerminalGrowthRate *float64 // Optional
+
+	// CAPM overrides used when DiscountRate is not supplied
+	RiskFreeRate      *float64 // Optional: defaults to config
+	EquityRiskPremium *float64 // Optional: defaults to config
+
+	// Stage structure
+	Mode                 string   // Optional: DCFModeSingleStage (default) or DCFModeThreeStage
+	HighGrowthYears      *int     // Optional: years at RevenueGrowthRate (default 5)
+	FadeYears            *int     // Optional: years fading to terminal values (default 5, three-stage only)
+	TerminalProfitMargin *float64 // Optional: profit margin reached at the end of the fade (default: no fade)
+	TerminalFCFMargin    *float64 // Optional: FCF margin reached at the end of the fade (default: no fade)
 }
 
+// DCF stage structures
+const (
+	DCFModeSingleStage = "single_stage" // Constant growth, then straight to terminal growth
+	DCFModeThreeStage  = "three_stage"  // High growth, linear fade, then terminal growth
+)
+
+// Stage length defaults and limits
+const (
+	defaultHighGrowthYears = 5
+	defaultFadeYears       = 5
+	maxStageYears          = 15
+)
+
+// Projection stage labels
+const (
+	stageHighGrowth = "high_growth"
+	stageFade       = "fade"
+)
+
 // CalculateDCF performs a Discounted Cash Flow valuation
 func CalculateDCF(companyData *finance.CompanyData, input *DCFInput) (*finance.ValuationResult, error) {
 	if companyData.LatestFinancials == nil {
@@ -26,10 +57,14 @@ func CalculateDCF(companyData *finance.CompanyData, input *DCFInput) (*finance.V
 		return nil, fmt.Errorf("shares outstanding not available")
 	}
 
+	if input != nil && input.Mode != "" && input.Mode != DCFModeSingleStage && input.Mode != DCFModeThreeStage {
+		return nil, fmt.Errorf("unknown DCF mode %q (use %s or %s)", input.Mode, DCFModeSingleStage, DCFModeThreeStage)
+	}
+
 	// Build assumptions with defaults or user inputs
 	assumptions := buildAssumptions(companyData, input)
 
-	// Project cash flows for 5 years
+	// Project cash flows through the high-growth and fade stages
 	projections := projectCashFlows(companyData.LatestFinancials, assumptions)
 
 	// Calculate terminal value
@@ -48,9 +83,9 @@ func CalculateDCF(companyData *finance.CompanyData, input *DCFInput) (*finance.V
 	// Enterprise Value = PV of cash flows + PV of terminal value
 	enterpriseValue := pvOfCashFlows + pvOfTerminalValue
 
-	// For simplicity, assume Enterprise Value ≈ Equity Value (ignoring debt/cash adjustments)
-	// In production, you'd subtract net debt and add cash
-	equityValue := enterpriseValue
+	// Bridge to equity: subtract net debt and the claims that rank ahead of common equity
+	bridge := buildEquityBridge(companyData.LatestFinancials)
+	equityValue := enterpriseValue - bridge.NetDebt - bridge.MinorityInterest - bridge.PreferredEquity
 
 	// Calculate fair value per share
 	fairValuePerShare := equityValue / (companyData.SharesOutstanding * 1_000_000) // Shares in millions
@@ -75,16 +110,33 @@ func CalculateDCF(companyData *finance.CompanyData, input *DCFInput) (*finance.V
 		Projections:       projections,
 		TerminalValue:     terminalValue,
 		EnterpriseValue:   enterpriseValue,
+		EquityBridge:      bridge,
+		EquityValue:       equityValue,
 		SharesOutstanding: companyData.SharesOutstanding,
 	}
 
 	return result, nil
 }
 
+// buildEquityBridge collects the balance sheet items between enterprise and equity value
+func buildEquityBridge(financials *finance.FinancialStatement) *finance.EquityBridge {
+	bridge := &finance.EquityBridge{
+		Cash:                 financials.Cash,
+		ShortTermInvestments: financials.ShortTermInvestments,
+		TotalDebt:            financials.TotalDebt,
+		MinorityInterest:     financials.MinorityInterest,
+		PreferredEquity:      financials.PreferredEquity,
+	}
+	bridge.NetDebt = bridge.TotalDebt - bridge.Cash - bridge.ShortTermInvestments
+	return bridge
+}
+
 // buildAssumptions creates DCF assumptions with fallback logic
 func buildAssumptions(data *finance.CompanyData, input *DCFInput) finance.DCFAssumptions {
 	assumptions := finance.DCFAssumptions{
-		ProjectionYears: 5,
+		Mode:            DCFModeSingleStage,
+		HighGrowthYears: defaultHighGrowthYears,
+		FadeYears:       defaultFadeYears,
 		Source:          "defaults",
 	}
 
@@ -106,19 +158,38 @@ func buildAssumptions(data *finance.CompanyData, input *DCFInput) finance.DCFAss
 		if input.TerminalGrowthRate != nil {
 			assumptions.TerminalGrowthRate = *input.TerminalGrowthRate
 		}
+		if input.Mode != "" {
+			assumptions.Mode = input.Mode
+		}
+		if input.HighGrowthYears != nil {
+			assumptions.HighGrowthYears = clampYears(*input.HighGrowthYears, 1)
+		}
+		if input.FadeYears != nil {
+			assumptions.FadeYears = clampYears(*input.FadeYears, 0)
+		}
 	}
 
-	// Apply defaults for missing values
-	if assumptions.RevenueGrowthRate == 0 {
-		// TODO: In future, fetch analyst consensus from Finnhub
-		// For now, use reasonable default based on company size
-		assumptions.RevenueGrowthRate = 0.08 // 8% default growth
-		if assumptions.Source != "user_input" {
-			assumptions.Source = "defaults"
+	if assumptions.Mode == DCFModeSingleStage {
+		assumptions.FadeYears = 0
+	}
+	assumptions.ProjectionYears = assumptions.HighGrowthYears + assumptions.FadeYears
+
+	// Apply defaults for missing values (an explicit 0 is a valid input)
+	if input == nil || input.RevenueGrowthRate == nil {
+		if consensus := GetAnalystConsensus(data); consensus != nil {
+			assumptions.RevenueGrowthRate = *consensus.RevenueGrowthRate
+			if assumptions.Source != "user_input" {
+				assumptions.Source = "analyst_consensus"
+			}
+		} else {
+			assumptions.RevenueGrowthRate = 0.08 // 8% default growth
+			if assumptions.Source != "user_input" {
+				assumptions.Source = "defaults"
+			}
 		}
 	}
 
-	if assumptions.ProfitMargin == 0 {
+	if input == nil || input.ProfitMargin == nil {
 		// Calculate historical profit margin if available
 		if data.LatestFinancials != nil && data.LatestFinancials.Revenue > 0 {
 			historicalMargin := data.LatestFinancials.NetIncome / data.LatestFinancials.Revenue
@@ -132,7 +203,7 @@ func buildAssumptions(data *finance.CompanyData, input *DCFInput) finance.DCFAss
 		}
 	}
 
-	if assumptions.FCFMargin == 0 {
+	if input == nil || input.FCFMargin == nil {
 		// Calculate historical FCF margin if available
 		if data.LatestFinancials != nil && data.LatestFinancials.Revenue > 0 && data.LatestFinancials.FreeCashFlow > 0 {
 			historicalFCFMargin := data.LatestFinancials.FreeCashFlow / data.LatestFinancials.Revenue
@@ -146,34 +217,97 @@ func buildAssumptions(data *finance.CompanyData, input *DCFInput) finance.DCFAss
 		}
 	}
 
-	if assumptions.DiscountRate == 0 {
-		assumptions.DiscountRate = 0.10 // 10% default required return
+	if input == nil || input.TerminalGrowthRate == nil {
+		assumptions.TerminalGrowthRate = 0.025 // 2.5% perpetual growth
 	}
 
-	if assumptions.TerminalGrowthRate == 0 {
-		assumptions.TerminalGrowthRate = 0.025 // 2.5% perpetual growth
+	// Margins fade toward their terminal targets; without a target they hold steady
+	assumptions.TerminalProfitMargin = assumptions.ProfitMargin
+	assumptions.TerminalFCFMargin = assumptions.FCFMargin
+	if input != nil && assumptions.FadeYears > 0 {
+		if input.TerminalProfitMargin != nil {
+			assumptions.TerminalProfitMargin = *input.TerminalProfitMargin
+		}
+		if input.TerminalFCFMargin != nil {
+			assumptions.TerminalFCFMargin = *input.TerminalFCFMargin
+		}
+	}
+
+	if input == nil || input.DiscountRate == nil {
+		wacc := calculateInputWACC(data, input)
+
+		// The perpetuity formula needs a discount rate above terminal growth
+		if wacc.WACC > assumptions.TerminalGrowthRate {
+			assumptions.DiscountRate = wacc.WACC
+		} else {
+			assumptions.DiscountRate = 0.10 // 10% default required return
+			wacc.Notes = append(wacc.Notes, "Computed WACC does not exceed terminal growth; using 10% default")
+		}
+		assumptions.WACC = wacc
 	}
 
 	return assumptions
 }
 
-// projectCashFlows generates 5-year cash flow projections
+// calculateInputWACC computes the WACC with the input's CAPM overrides, falling back to config
+func calculateInputWACC(data *finance.CompanyData, input *DCFInput) *finance.WACCBreakdown {
+	cfg := config.GetConfig()
+	riskFreeRate := cfg.RiskFreeRate
+	equityRiskPremium := cfg.EquityRiskPremium
+	if input != nil && input.RiskFreeRate != nil {
+		riskFreeRate = *input.RiskFreeRate
+	}
+	if input != nil && input.EquityRiskPremium != nil {
+		equityRiskPremium = *input.EquityRiskPremium
+	}
+
+	return CalculateWACC(data, riskFreeRate, equityRiskPremium)
+}
+
+// clampYears bounds a user-supplied stage length
+func clampYears(years, min int) int {
+	if years < min {
+		return min
+	}
+	if years > maxStageYears {
+		return maxStageYears
+	}
+	return years
+}
+
+// projectCashFlows generates yearly projections: revenue grows at RevenueGrowthRate
+// for HighGrowthYears, then growth and margins fade linearly over FadeYears so the
+// final year reaches the terminal growth rate and terminal margins
 func projectCashFlows(financials *finance.FinancialStatement, assumptions finance.DCFAssumptions) []finance.DCFProjection {
 	projections := make([]finance.DCFProjection, assumptions.ProjectionYears)
 
-	currentRevenue := financials.Revenue
+	projectedRevenue := financials.Revenue
 
 	for i := 0; i < assumptions.ProjectionYears; i++ {
 		year := i + 1
 
-		// Project revenue with growth rate
-		projectedRevenue := currentRevenue * math.Pow(1+assumptions.RevenueGrowthRate, float64(year))
+		growthRate := assumptions.RevenueGrowthRate
+		profitMargin := assumptions.ProfitMargin
+		fcfMargin := assumptions.FCFMargin
+		stage := stageHighGrowth
+
+		if year > assumptions.HighGrowthYears {
+			// Linear fade: fraction of the way from high-growth to terminal values
+			progress := float64(year-assumptions.HighGrowthYears) / float64(assumptions.FadeYears)
+			growthRate = fade(assumptions.RevenueGrowthRate, assumptions.TerminalGrowthRate, progress)
+			profitMargin = fade(assumptions.ProfitMargin, assumptions.TerminalProfitMargin, progress)
+			fcfMargin = fade(assumptions.FCFMargin, assumptions.TerminalFCFMargin, progress)
+			stage = stageFade
+		}
+
+		// Project revenue with this year's growth rate
+		projectedRevenue *= 1 + growthRate
 
 		// Project net income
-		projectedNetIncome := projectedRevenue * assumptions.ProfitMargin
+		projectedNetIncome := projectedRevenue * profitMargin
 
 		// Project free cash flow
-		projectedFCF := projectedRevenue * assumptions.FCFMargin
+		projectedFCF := projectedRevenue * fcfMargin
 
 		// Calculate discount factor
 		discountFactor := math.Pow(1+assumptions.DiscountRate, float64(year))
@@ -183,6 +317,9 @@ func projectCashFlows(financials *finance.FinancialStatement, assumptions financ
 
 		projections[i] = finance.DCFProjection{
 			Year:           year,
+			Stage:          stage,
+			GrowthRate:     growthRate,
+			FCFMargin:      fcfMargin,
 			Revenue:        projectedRevenue,
 			NetIncome:      projectedNetIncome,
 			FreeCashFlow:   projectedFCF,
@@ -194,6 +331,11 @@ func projectCashFlows(financials *finance.FinancialStatement, assumptions financ
 	return projections
 }
 
+// fade linearly interpolates from start to target (progress 0 → 1)
+func fade(start, target, progress float64) float64 {
+	return start + (target-start)*progress
+}
+
 // calculateTerminalValue calculates the terminal value using perpetuity growth model
 func calculateTerminalValue(projections []finance.DCFProjection, assumptions finance.DCFAssumptions) float64 {
 	if len(projections) == 0 {
@@ -212,7 +354,8 @@ func calculateTerminalValue(projections []finance.DCFProjection, assumptions fin
 	return terminalValue
 }
 
-// CalculateFairValueSimple is a simplified valuation method based on P/E multiples
+// CalculateFairValueSimple prices earnings at a target P/E (default 15).
+// The comparables valuation supplies peer P/E multiples as the target.
 func CalculateFairValueSimple(companyData *finance.CompanyData, targetPE float64) (float64, error) {
 	if companyData.LatestFinancials == nil || companyData.SharesOutstanding <= 0 {
 		return 0, fmt.Errorf("insufficient data for simple valuation")
@@ -228,10 +371,13 @@ func CalculateFairValueSimple(companyData *finance.CompanyData, targetPE float64
 	return fairValue, nil
 }
 
-// GetAnalystConsensus would fetch analyst estimates from Finnhub
-// For MVP, this returns nil to use defaults
-func GetAnalystConsensus(ticker string) *DCFInput {
-	// TODO: Implement Finnhub analyst estimates API call
-	// Endpoint: /stock/price-target and /stock/recommendation
-	return nil
+// GetAnalystConsensus derives DCF inputs from the analyst estimates fetched
+// into companyData: revenue growth annualized from the latest annual revenue to
+// the furthest consensus year. Returns nil without usable revenue estimates.
+func GetAnalystConsensus(companyData *finance.CompanyData) *DCFInput {
+	growth := consensusRevenueGrowth(companyData)
+	if growth == nil {
+		return nil
+	}
+	return &DCFInput{RevenueGrowthRate: growth}
 }
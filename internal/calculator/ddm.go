@@ -0,0 +1,227 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sshetty/finEdSkywalker/internal/config"
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// DDM structures
+const (
+	DDMModeGordon   = "gordon"    // Dividends grow at the terminal rate forever
+	DDMModeTwoStage = "two_stage" // High dividend growth, then the terminal rate
+)
+
+// Dividend growth defaults and limits
+const (
+	minDividendHistory = 3    // Annual dividends needed for a historical CAGR
+	maxDividendGrowth  = 0.20 // Cap on derived high-stage dividend growth
+)
+
+// DDMInput represents user-provided or default inputs for the dividend discount model
+type DDMInput struct {
+	Mode               string   // Optional: DDMModeTwoStage (default) or DDMModeGordon
+	CostOfEquity       *float64 // Optional: defaults to CAPM
+	DividendGrowthRate *float64 // Optional: high-stage growth (default: historical CAGR or sustainable growth)
+	HighGrowthYears    *int     // Optional: years at DividendGrowthRate (default 5)
+	TerminalGrowthRate *float64 // Optional: perpetual dividend growth (default 2.5%)
+
+	// CAPM overrides used when CostOfEquity is not supplied
+	RiskFreeRate      *float64
+	EquityRiskPremium *float64
+}
+
+// CalculateDDM values a share as the present value of its future dividends.
+// Gordon growth: Value = D0 × (1 + g) / (ke - g)
+// Two-stage: PV of dividends growing at g1 for N years + PV of the Gordon value at year N
+func CalculateDDM(companyData *finance.CompanyData, input *DDMInput) (*finance.ValuationResult, error) {
+	if input == nil {
+		input = &DDMInput{}
+	}
+
+	mode := input.Mode
+	if mode == "" {
+		mode = DDMModeTwoStage
+	}
+	if mode != DDMModeGordon && mode != DDMModeTwoStage {
+		return nil, fmt.Errorf("unknown DDM mode %q (use %s or %s)", input.Mode, DDMModeGordon, DDMModeTwoStage)
+	}
+
+	ddm := &finance.DDMResult{Mode: mode}
+
+	// Current dividend (D0): trailing twelve months, else the latest fiscal year
+	if companyData.LatestFinancials != nil {
+		ddm.CurrentDividendPerShare = companyData.LatestFinancials.DividendsPerShare
+		if companyData.LatestFinancials.EPS > 0 {
+			ddm.PayoutRatio = ddm.CurrentDividendPerShare / companyData.LatestFinancials.EPS
+		}
+	}
+	if ddm.CurrentDividendPerShare <= 0 && companyData.HistoricalData != nil {
+		annual := companyData.HistoricalData.AnnualStatements
+		if len(annual) > 0 {
+			latest := annual[len(annual)-1]
+			ddm.CurrentDividendPerShare = latest.DividendsPerShare
+			if latest.EPS > 0 {
+				ddm.PayoutRatio = latest.DividendsPerShare / latest.EPS
+			}
+		}
+	}
+	if ddm.CurrentDividendPerShare <= 0 {
+		return nil, fmt.Errorf("no dividends per share reported; the DDM only applies to dividend-paying companies")
+	}
+	if ddm.PayoutRatio > 1 {
+		ddm.Notes = append(ddm.Notes, "Payout ratio above 100%; dividends exceed earnings and may not be sustainable")
+	}
+
+	assumptions := finance.DCFAssumptions{
+		Mode:   mode,
+		Source: "defaults",
+	}
+
+	// Cost of equity: user input, else CAPM
+	if input.CostOfEquity != nil {
+		ddm.CostOfEquity = *input.CostOfEquity
+		assumptions.Source = "user_input"
+	} else {
+		cfg := config.GetConfig()
+		riskFreeRate := cfg.RiskFreeRate
+		equityRiskPremium := cfg.EquityRiskPremium
+		if input.RiskFreeRate != nil {
+			riskFreeRate = *input.RiskFreeRate
+		}
+		if input.EquityRiskPremium != nil {
+			equityRiskPremium = *input.EquityRiskPremium
+		}
+		assumptions.WACC = CalculateWACC(companyData, riskFreeRate, equityRiskPremium)
+		ddm.CostOfEquity = assumptions.WACC.CostOfEquity
+	}
+
+	ddm.TerminalGrowthRate = 0.025 // 2.5% perpetual growth
+	if input.TerminalGrowthRate != nil {
+		ddm.TerminalGrowthRate = *input.TerminalGrowthRate
+	}
+
+	// The Gordon formula needs a cost of equity above perpetual growth
+	if ddm.CostOfEquity <= ddm.TerminalGrowthRate {
+		return nil, fmt.Errorf("cost of equity (%.2f%%) must exceed terminal growth (%.2f%%)",
+			ddm.CostOfEquity*100, ddm.TerminalGrowthRate*100)
+	}
+
+	var fairValue float64
+	if mode == DDMModeGordon {
+		ddm.DividendGrowthRate = ddm.TerminalGrowthRate
+		ddm.GrowthSource = "terminal_growth"
+
+		nextDividend := ddm.CurrentDividendPerShare * (1 + ddm.TerminalGrowthRate)
+		fairValue = nextDividend / (ddm.CostOfEquity - ddm.TerminalGrowthRate)
+	} else {
+		ddm.HighGrowthYears = defaultHighGrowthYears
+		if input.HighGrowthYears != nil {
+			ddm.HighGrowthYears = clampYears(*input.HighGrowthYears, 1)
+		}
+
+		if input.DividendGrowthRate != nil {
+			ddm.DividendGrowthRate = *input.DividendGrowthRate
+			ddm.GrowthSource = "user_input"
+		} else {
+			ddm.DividendGrowthRate, ddm.GrowthSource = defaultDividendGrowth(companyData, ddm)
+		}
+
+		dividend := ddm.CurrentDividendPerShare
+		pvOfDividends := 0.0
+		for year := 1; year <= ddm.HighGrowthYears; year++ {
+			dividend *= 1 + ddm.DividendGrowthRate
+			discountFactor := math.Pow(1+ddm.CostOfEquity, float64(year))
+			presentValue := dividend / discountFactor
+			pvOfDividends += presentValue
+
+			ddm.Dividends = append(ddm.Dividends, finance.DividendProjection{
+				Year:             year,
+				DividendPerShare: dividend,
+				DiscountFactor:   discountFactor,
+				PresentValue:     presentValue,
+			})
+		}
+
+		// Terminal value at year N = D(N+1) / (ke - g)
+		ddm.TerminalValue = dividend * (1 + ddm.TerminalGrowthRate) / (ddm.CostOfEquity - ddm.TerminalGrowthRate)
+		ddm.PVTerminalValue = ddm.TerminalValue / math.Pow(1+ddm.CostOfEquity, float64(ddm.HighGrowthYears))
+		fairValue = pvOfDividends + ddm.PVTerminalValue
+	}
+
+	assumptions.DiscountRate = ddm.CostOfEquity
+	assumptions.TerminalGrowthRate = ddm.TerminalGrowthRate
+	assumptions.HighGrowthYears = ddm.HighGrowthYears
+	assumptions.ProjectionYears = ddm.HighGrowthYears
+
+	currentPrice := 0.0
+	if companyData.Quote != nil {
+		currentPrice = companyData.Quote.CurrentPrice
+	}
+
+	upsidePercent := 0.0
+	if currentPrice > 0 {
+		upsidePercent = ((fairValue - currentPrice) / currentPrice) * 100
+	}
+
+	model := "DDM (Two-Stage)"
+	if mode == DDMModeGordon {
+		model = "DDM (Gordon Growth)"
+	}
+
+	return &finance.ValuationResult{
+		FairValuePerShare: fairValue,
+		CurrentPrice:      currentPrice,
+		UpsidePercent:     upsidePercent,
+		Model:             model,
+		Assumptions:       assumptions,
+		SharesOutstanding: companyData.SharesOutstanding,
+		DDM:               ddm,
+	}, nil
+}
+
+// defaultDividendGrowth picks the high-stage dividend growth rate: the historical
+// dividend CAGR, else sustainable growth (ROE × retention ratio), else terminal growth
+func defaultDividendGrowth(companyData *finance.CompanyData, ddm *finance.DDMResult) (float64, string) {
+	if companyData.HistoricalData != nil {
+		var paying []finance.FinancialStatement
+		for _, statement := range companyData.HistoricalData.AnnualStatements {
+			if statement.DividendsPerShare > 0 {
+				paying = append(paying, statement)
+			}
+		}
+		if len(paying) >= minDividendHistory {
+			// Compound over the fiscal years elapsed, so gaps in the history don't overstate growth
+			first, last := paying[0], paying[len(paying)-1]
+			if years := float64(last.FiscalYear - first.FiscalYear); years > 0 {
+				cagr := math.Pow(last.DividendsPerShare/first.DividendsPerShare, 1/years) - 1
+				return clampDividendGrowth(cagr, ddm), "historical_cagr"
+			}
+		}
+	}
+
+	financials := companyData.LatestFinancials
+	if financials != nil && financials.ShareholdersEquity > 0 && ddm.PayoutRatio > 0 && ddm.PayoutRatio < 1 {
+		roe := financials.NetIncome / financials.ShareholdersEquity
+		if roe > 0 {
+			return clampDividendGrowth(roe*(1-ddm.PayoutRatio), ddm), "sustainable_growth"
+		}
+	}
+
+	return ddm.TerminalGrowthRate, "terminal_growth"
+}
+
+// clampDividendGrowth keeps a derived growth rate between zero and maxDividendGrowth
+func clampDividendGrowth(growth float64, ddm *finance.DDMResult) float64 {
+	if growth < 0 {
+		ddm.Notes = append(ddm.Notes, "Dividends have been shrinking; assuming no high-stage growth")
+		return 0
+	}
+	if growth > maxDividendGrowth {
+		ddm.Notes = append(ddm.Notes, fmt.Sprintf("Derived dividend growth %.1f%% capped at %.0f%%", growth*100, maxDividendGrowth*100))
+		return maxDividendGrowth
+	}
+	return growth
+}
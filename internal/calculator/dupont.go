@@ -0,0 +1,158 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// dupontStableTolerance is the relative change below which a factor is "stable"
+const dupontStableTolerance = 0.02
+
+// DuPont factor names
+const (
+	DuPontNetMargin        = "net_margin"
+	DuPontAssetTurnover    = "asset_turnover"
+	DuPontEquityMultiplier = "equity_multiplier"
+	DuPontTaxBurden        = "tax_burden"
+	DuPontInterestBurden   = "interest_burden"
+	DuPontOperatingMargin  = "operating_margin"
+)
+
+// CalculateDuPont decomposes ROE for each of the last five fiscal years using
+// year-end balances, matching the ROE history. Years with non-positive revenue,
+// assets or equity are skipped. Returns nil when no year can be decomposed.
+func CalculateDuPont(companyData *finance.CompanyData) *finance.DuPontAnalysis {
+	if companyData.HistoricalData == nil || len(companyData.HistoricalData.AnnualStatements) == 0 {
+		return nil
+	}
+
+	annual := companyData.HistoricalData.AnnualStatements
+	if len(annual) > HistoryYears {
+		annual = annual[len(annual)-HistoryYears:]
+	}
+
+	analysis := &finance.DuPontAnalysis{}
+	for _, statement := range annual {
+		if statement.Revenue <= 0 || statement.TotalAssets <= 0 || statement.ShareholdersEquity <= 0 {
+			continue
+		}
+
+		year := finance.DuPontYear{
+			FiscalYear:       statement.FiscalYear,
+			ROE:              statement.NetIncome / statement.ShareholdersEquity,
+			NetMargin:        statement.NetIncome / statement.Revenue,
+			AssetTurnover:    statement.Revenue / statement.TotalAssets,
+			EquityMultiplier: statement.TotalAssets / statement.ShareholdersEquity,
+		}
+
+		// Net Margin = Tax Burden × Interest Burden × Operating Margin
+		if statement.PretaxIncome != 0 && statement.OperatingIncome != 0 {
+			year.FiveFactorAvailable = true
+			year.TaxBurden = statement.NetIncome / statement.PretaxIncome
+			year.InterestBurden = statement.PretaxIncome / statement.OperatingIncome
+			year.OperatingMargin = statement.OperatingIncome / statement.Revenue
+		}
+
+		analysis.Years = append(analysis.Years, year)
+	}
+
+	if len(analysis.Years) == 0 {
+		return nil
+	}
+
+	first := analysis.Years[0]
+	last := analysis.Years[len(analysis.Years)-1]
+	// Five-factor trends need every year: a missing year would read as a factor collapsing to zero
+	fiveFactor := true
+	for _, year := range analysis.Years {
+		fiveFactor = fiveFactor && year.FiveFactorAvailable
+	}
+
+	factors := []struct {
+		name  string
+		value func(finance.DuPontYear) float64
+		five  bool
+	}{
+		{DuPontNetMargin, func(y finance.DuPontYear) float64 { return y.NetMargin }, false},
+		{DuPontAssetTurnover, func(y finance.DuPontYear) float64 { return y.AssetTurnover }, false},
+		{DuPontEquityMultiplier, func(y finance.DuPontYear) float64 { return y.EquityMultiplier }, false},
+		{DuPontTaxBurden, func(y finance.DuPontYear) float64 { return y.TaxBurden }, true},
+		{DuPontInterestBurden, func(y finance.DuPontYear) float64 { return y.InterestBurden }, true},
+		{DuPontOperatingMargin, func(y finance.DuPontYear) float64 { return y.OperatingMargin }, true},
+	}
+
+	// Log changes are additive across multiplied factors, so each factor's share of
+	// ln(ROE last / ROE first) is its own log change over the ROE log change
+	roeLogChange := 0.0
+	decomposable := first.ROE > 0 && last.ROE > 0
+	if decomposable {
+		roeLogChange = math.Log(last.ROE / first.ROE)
+	}
+
+	var driver *finance.DuPontTrend
+	for _, factor := range factors {
+		if factor.five && !fiveFactor {
+			continue
+		}
+
+		trend := finance.DuPontTrend{Factor: factor.name}
+		for _, year := range analysis.Years {
+			trend.Values = append(trend.Values, factor.value(year))
+		}
+
+		start, end := factor.value(first), factor.value(last)
+		trend.Change = end - start
+		trend.Direction = "stable"
+		if start != 0 {
+			relative := trend.Change / math.Abs(start)
+			if relative > dupontStableTolerance {
+				trend.Direction = "rising"
+			} else if relative < -dupontStableTolerance {
+				trend.Direction = "falling"
+			}
+		}
+
+		if decomposable && roeLogChange != 0 && start > 0 && end > 0 {
+			trend.ROEContribution = math.Log(end/start) / roeLogChange
+		}
+
+		analysis.Trends = append(analysis.Trends, trend)
+
+		// The largest three-factor driver explains the ROE change in the summary
+		if !factor.five {
+			current := &analysis.Trends[len(analysis.Trends)-1]
+			if driver == nil || math.Abs(current.ROEContribution) > math.Abs(driver.ROEContribution) {
+				driver = current
+			}
+		}
+	}
+
+	analysis.Summary = dupontSummary(first, last, driver, decomposable)
+	return analysis
+}
+
+// dupontSummary explains the ROE change over the window in one sentence
+func dupontSummary(first, last finance.DuPontYear, driver *finance.DuPontTrend, decomposable bool) string {
+	if first.FiscalYear == last.FiscalYear {
+		return fmt.Sprintf("FY%d ROE of %.1f%% = %.1f%% net margin × %.2f asset turnover × %.2f equity multiplier",
+			last.FiscalYear, last.ROE*100, last.NetMargin*100, last.AssetTurnover, last.EquityMultiplier)
+	}
+
+	summary := fmt.Sprintf("ROE moved from %.1f%% in FY%d to %.1f%% in FY%d",
+		first.ROE*100, first.FiscalYear, last.ROE*100, last.FiscalYear)
+
+	switch {
+	case !decomposable:
+		summary += "; factor contributions are not meaningful with negative earnings"
+	case driver == nil || driver.ROEContribution == 0:
+		summary += "; the factors were broadly unchanged"
+	default:
+		summary += fmt.Sprintf("; the main driver was %s (%s)",
+			strings.ReplaceAll(driver.Factor, "_", " "), driver.Direction)
+	}
+
+	return summary
+}
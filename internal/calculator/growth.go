@@ -0,0 +1,122 @@
+package calculator
+
+import (
+	"math"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// EPS growth sources
+const (
+	GrowthSourceAnalyst    = "analyst_estimates"
+	GrowthSourceHistorical = "historical_eps_cagr"
+)
+
+// EPS growth inputs
+const (
+	minEPSHistoryYears     = 3   // Annual statements needed for a historical CAGR
+	maxEstimateHorizon     = 3   // Furthest consensus year used for analyst growth
+	maxReasonableEPSGrowth = 1.0 // Higher usually means a near-zero base year
+)
+
+// EPSGrowth is an annual EPS growth rate and where it came from
+type EPSGrowth struct {
+	Rate   float64
+	Source string
+	Years  int // Years the growth was measured over
+}
+
+// EstimateEPSGrowth returns annualized EPS growth from analyst consensus when
+// available, else the historical EPS CAGR from EDGAR. Returns nil when neither
+// can be computed (e.g. losses at either end of the window).
+func EstimateEPSGrowth(data *finance.CompanyData) *EPSGrowth {
+	if growth := analystEPSGrowth(data); growth != nil {
+		return growth
+	}
+	return historicalEPSGrowth(data)
+}
+
+// analystEPSGrowth annualizes growth from the latest fiscal year's EPS to the
+// furthest consensus estimate within maxEstimateHorizon years
+func analystEPSGrowth(data *finance.CompanyData) *EPSGrowth {
+	baseEPS, baseYear := latestAnnualEPS(data)
+	if baseEPS <= 0 {
+		return nil
+	}
+
+	if data.AnalystConsensus == nil {
+		return nil
+	}
+
+	var target *finance.EPSEstimate
+	estimates := data.AnalystConsensus.EPSEstimates
+	for i := range estimates {
+		estimate := &estimates[i]
+		if estimate.FiscalYear > baseYear && estimate.FiscalYear <= baseYear+maxEstimateHorizon && estimate.EPSAvg > 0 {
+			target = estimate
+		}
+	}
+	if target == nil {
+		return nil
+	}
+
+	years := target.FiscalYear - baseYear
+	rate := math.Pow(target.EPSAvg/baseEPS, 1/float64(years)) - 1
+	if rate > maxReasonableEPSGrowth {
+		return nil
+	}
+
+	return &EPSGrowth{Rate: rate, Source: GrowthSourceAnalyst, Years: years}
+}
+
+// historicalEPSGrowth is the EPS CAGR over the last five annual statements.
+// Both ends must be positive for the CAGR to be meaningful.
+func historicalEPSGrowth(data *finance.CompanyData) *EPSGrowth {
+	if data.HistoricalData == nil || len(data.HistoricalData.AnnualStatements) < minEPSHistoryYears {
+		return nil
+	}
+
+	annual := data.HistoricalData.AnnualStatements
+	if len(annual) > HistoryYears {
+		annual = annual[len(annual)-HistoryYears:]
+	}
+
+	first := annual[0]
+	last := annual[len(annual)-1]
+	years := last.FiscalYear - first.FiscalYear
+	if years <= 0 || first.EPS <= 0 || last.EPS <= 0 {
+		return nil
+	}
+
+	rate := math.Pow(last.EPS/first.EPS, 1/float64(years)) - 1
+	return &EPSGrowth{Rate: rate, Source: GrowthSourceHistorical, Years: years}
+}
+
+// nextYearEPSEstimate returns the consensus for the fiscal year after the latest annual statement
+func nextYearEPSEstimate(data *finance.CompanyData) *finance.EPSEstimate {
+	if data.AnalystConsensus == nil {
+		return nil
+	}
+
+	_, baseYear := latestAnnualEPS(data)
+	estimates := data.AnalystConsensus.EPSEstimates
+	for i := range estimates {
+		if estimates[i].FiscalYear > baseYear {
+			return &estimates[i]
+		}
+	}
+	return nil
+}
+
+// latestAnnualEPS returns the most recent annual diluted EPS and its fiscal year.
+// Without annual history it falls back to the latest financials.
+func latestAnnualEPS(data *finance.CompanyData) (float64, int) {
+	if data.HistoricalData != nil && len(data.HistoricalData.AnnualStatements) > 0 {
+		latest := data.HistoricalData.AnnualStatements[len(data.HistoricalData.AnnualStatements)-1]
+		return latest.EPS, latest.FiscalYear
+	}
+	if data.LatestFinancials != nil {
+		return data.LatestFinancials.EPS, data.LatestFinancials.FiscalYear
+	}
+	return 0, 0
+}
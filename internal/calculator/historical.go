@@ -0,0 +1,95 @@
+package calculator
+
+import (
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// HistoryYears is the number of fiscal years used for historical averages and ratios
+const HistoryYears = 5
+
+// PopulateHistoricalMetrics computes per-fiscal-year P/E, ROE, FCF yield,
+// debt-to-equity and ROIC from the annual statements, pairing each year's
+// earnings with the share price at fiscal year end. yearEndPrices is keyed by
+// fiscal year.
+func PopulateHistoricalMetrics(data *finance.CompanyData, yearEndPrices map[int]float64) {
+	history := data.HistoricalData
+	if history == nil || len(history.AnnualStatements) == 0 {
+		return
+	}
+
+	annual := history.AnnualStatements
+	if len(annual) > HistoryYears {
+		annual = annual[len(annual)-HistoryYears:]
+	}
+
+	history.FiscalYears = make([]int, len(annual))
+	history.PERatios = make([]float64, len(annual))
+	history.ROEHistory = make([]float64, len(annual))
+	history.FCFYieldHistory = make([]float64, len(annual))
+	history.DebtToEquityHistory = make([]float64, len(annual))
+	history.ROICHistory = make([]float64, len(annual))
+
+	for i, statement := range annual {
+		history.FiscalYears[i] = statement.FiscalYear
+		price := yearEndPrices[statement.FiscalYear]
+		shares := historicalShares(statement, data.SharesOutstanding)
+
+		// P/E = year-end price / that year's EPS
+		eps := statement.EPS
+		if eps == 0 && shares > 0 {
+			eps = statement.NetIncome / shares
+		}
+		if price > 0 && eps > 0 {
+			history.PERatios[i] = price / eps
+		}
+
+		// ROE = Net Income / Shareholders' Equity (as percentage)
+		if statement.ShareholdersEquity > 0 {
+			history.ROEHistory[i] = (statement.NetIncome / statement.ShareholdersEquity) * 100
+			history.DebtToEquityHistory[i] = statement.TotalDebt / statement.ShareholdersEquity
+		}
+
+		// ROIC = NOPAT / Invested Capital (as percentage)
+		if roic, ok := calculateROIC(statement); ok {
+			history.ROICHistory[i] = roic * 100
+		}
+
+		// FCF Yield = Free Cash Flow / year-end Market Cap (as percentage)
+		if price > 0 && shares > 0 {
+			history.FCFYieldHistory[i] = (statement.FreeCashFlow / (price * shares)) * 100
+		}
+	}
+
+	if avg := averageNonZero(history.PERatios); avg != nil {
+		history.PERatioAvg5Year = *avg
+	}
+}
+
+// historicalShares estimates a year's diluted share count from net income and EPS,
+// falling back to today's shares outstanding (reported in millions)
+func historicalShares(statement finance.FinancialStatement, currentSharesMillions float64) float64 {
+	if statement.EPS != 0 && statement.NetIncome != 0 {
+		if shares := statement.NetIncome / statement.EPS; shares > 0 {
+			return shares
+		}
+	}
+	return currentSharesMillions * 1_000_000
+}
+
+// averageNonZero averages the non-zero values of a history series.
+// Zero marks a year where the metric was not meaningful (e.g., negative earnings).
+func averageNonZero(values []float64) *float64 {
+	sum := 0.0
+	count := 0
+	for _, v := range values {
+		if v != 0 {
+			sum += v
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	avg := sum / float64(count)
+	return &avg
+}
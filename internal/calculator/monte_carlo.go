@@ -0,0 +1,301 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// Monte Carlo settings
+const (
+	DefaultSimulations  = 1000
+	MaxSimulations      = 10000
+	DefaultSeed         = 42
+	histogramBins       = 20
+	maxTruncatedRedraws = 100 // Redraws for a bounded normal before clamping
+)
+
+// Supported distribution types
+const (
+	DistNormal     = "normal"     // normal:mean,stddev[,min,max]
+	DistTriangular = "triangular" // triangular:min,mode,max
+	DistUniform    = "uniform"    // uniform:min,max
+)
+
+// MonteCarloInput configures a simulation run
+type MonteCarloInput struct {
+	Base          *DCFInput                       // Point estimates for assumptions without a distribution
+	Distributions map[string]finance.Distribution // Keyed by assumption (see Sensitivity* constants)
+	Simulations   int
+	Seed          int64
+}
+
+// ParseDistribution parses a distribution spec such as "normal:0.08,0.02",
+// "normal:0.08,0.02,0,0.2", "triangular:0.02,0.08,0.15" or "uniform:0.05,0.12"
+func ParseDistribution(spec string) (finance.Distribution, error) {
+	dist := finance.Distribution{}
+
+	kind, paramList, found := strings.Cut(spec, ":")
+	if !found {
+		return dist, fmt.Errorf("distribution %q must look like type:param1,param2", spec)
+	}
+
+	var params []float64
+	for _, p := range strings.Split(paramList, ",") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return dist, fmt.Errorf("distribution %q has a non-numeric parameter %q", spec, p)
+		}
+		params = append(params, f)
+	}
+
+	dist.Type = strings.ToLower(kind)
+	switch dist.Type {
+	case DistNormal:
+		if len(params) != 2 && len(params) != 4 {
+			return dist, fmt.Errorf("normal distribution needs mean,stddev[,min,max]")
+		}
+		dist.Mean, dist.StdDev = params[0], params[1]
+		if dist.StdDev < 0 {
+			return dist, fmt.Errorf("normal distribution stddev must not be negative")
+		}
+		if len(params) == 4 {
+			dist.Min, dist.Max = floatPtr(params[2]), floatPtr(params[3])
+		}
+	case DistTriangular:
+		if len(params) != 3 {
+			return dist, fmt.Errorf("triangular distribution needs min,mode,max")
+		}
+		dist.Min, dist.Mode, dist.Max = floatPtr(params[0]), floatPtr(params[1]), floatPtr(params[2])
+		if !(params[0] <= params[1] && params[1] <= params[2]) || params[0] == params[2] {
+			return dist, fmt.Errorf("triangular distribution needs min <= mode <= max with min < max")
+		}
+	case DistUniform:
+		if len(params) != 2 {
+			return dist, fmt.Errorf("uniform distribution needs min,max")
+		}
+		dist.Min, dist.Max = floatPtr(params[0]), floatPtr(params[1])
+	default:
+		return dist, fmt.Errorf("unknown distribution type %q (use normal, triangular or uniform)", kind)
+	}
+
+	if dist.Min != nil && dist.Max != nil && *dist.Max < *dist.Min {
+		return dist, fmt.Errorf("distribution max must not be below min")
+	}
+
+	return dist, nil
+}
+
+// CalculateMonteCarlo runs seeded DCF simulations with assumptions drawn from
+// their distributions. The same seed and inputs always produce the same result.
+func CalculateMonteCarlo(companyData *finance.CompanyData, mc MonteCarloInput) (*finance.ValuationResult, error) {
+	for assumption := range mc.Distributions {
+		if _, ok := sensitivityDefaults[assumption]; !ok {
+			return nil, fmt.Errorf("unknown assumption %q for Monte Carlo", assumption)
+		}
+	}
+
+	if mc.Simulations <= 0 {
+		mc.Simulations = DefaultSimulations
+	}
+	if mc.Simulations > MaxSimulations {
+		return nil, fmt.Errorf("simulations must be at most %d", MaxSimulations)
+	}
+
+	input := mc.Base
+	if input == nil {
+		input = &DCFInput{}
+	}
+
+	base, err := CalculateDCF(companyData, input)
+	if err != nil {
+		return nil, err
+	}
+
+	distributions := mc.Distributions
+	if len(distributions) == 0 {
+		distributions = defaultDistributions(base.Assumptions)
+	}
+
+	pinned := *input
+	pinned.RevenueGrowthRate = floatPtr(base.Assumptions.RevenueGrowthRate)
+	pinned.ProfitMargin = floatPtr(base.Assumptions.ProfitMargin)
+	pinned.FCFMargin = floatPtr(base.Assumptions.FCFMargin)
+	pinned.DiscountRate = floatPtr(base.Assumptions.DiscountRate)
+	pinned.TerminalGrowthRate = floatPtr(base.Assumptions.TerminalGrowthRate)
+
+	// Draw assumptions in a fixed order so map iteration can't change the sequence
+	assumptions := make([]string, 0, len(distributions))
+	for assumption := range distributions {
+		assumptions = append(assumptions, assumption)
+	}
+	sort.Strings(assumptions)
+
+	rng := rand.New(rand.NewSource(mc.Seed))
+	fairValues := make([]float64, 0, mc.Simulations)
+
+	for i := 0; i < mc.Simulations; i++ {
+		trial := pinned
+		for _, assumption := range assumptions {
+			setSensitivityAssumption(&trial, assumption, sample(rng, distributions[assumption]))
+		}
+
+		// Skip draws where the perpetuity formula is undefined
+		if *trial.DiscountRate <= *trial.TerminalGrowthRate {
+			continue
+		}
+
+		valuation, err := CalculateDCF(companyData, &trial)
+		if err != nil {
+			continue
+		}
+		fairValues = append(fairValues, valuation.FairValuePerShare)
+	}
+
+	if len(fairValues) == 0 {
+		return nil, fmt.Errorf("no valid simulations: every draw had a discount rate at or below terminal growth")
+	}
+
+	sort.Float64s(fairValues)
+
+	result := &finance.MonteCarloResult{
+		Simulations:      mc.Simulations,
+		ValidSimulations: len(fairValues),
+		Seed:             mc.Seed,
+		Distributions:    distributions,
+		Percentiles: finance.MonteCarloPercentiles{
+			P5:  percentile(fairValues, 0.05),
+			P25: percentile(fairValues, 0.25),
+			P50: percentile(fairValues, 0.50),
+			P75: percentile(fairValues, 0.75),
+			P95: percentile(fairValues, 0.95),
+		},
+		Histogram: histogram(fairValues, histogramBins),
+	}
+
+	sum := 0.0
+	for _, v := range fairValues {
+		sum += v
+	}
+	result.Mean = sum / float64(len(fairValues))
+
+	variance := 0.0
+	for _, v := range fairValues {
+		variance += (v - result.Mean) * (v - result.Mean)
+	}
+	result.StdDev = math.Sqrt(variance / float64(len(fairValues)))
+
+	if base.CurrentPrice > 0 {
+		undervalued := 0
+		for _, v := range fairValues {
+			if v > base.CurrentPrice {
+				undervalued++
+			}
+		}
+		result.ProbabilityUndervalued = float64(undervalued) / float64(len(fairValues))
+	}
+
+	// Report the median as the headline fair value
+	valuation := base
+	valuation.Model = "Monte Carlo DCF"
+	valuation.FairValuePerShare = result.Percentiles.P50
+	valuation.UpsidePercent = 0
+	if valuation.CurrentPrice > 0 {
+		valuation.UpsidePercent = ((valuation.FairValuePerShare - valuation.CurrentPrice) / valuation.CurrentPrice) * 100
+	}
+	// Point-estimate details describe only the base case, not the distribution
+	valuation.Projections = nil
+	valuation.TerminalValue = 0
+	valuation.EnterpriseValue = 0
+	valuation.EquityValue = 0
+	valuation.MonteCarlo = result
+
+	return valuation, nil
+}
+
+// defaultDistributions spreads the key assumptions around the base case
+// when the caller doesn't supply any distributions
+func defaultDistributions(base finance.DCFAssumptions) map[string]finance.Distribution {
+	return map[string]finance.Distribution{
+		SensitivityRevenueGrowth: {Type: DistNormal, Mean: base.RevenueGrowthRate, StdDev: 0.03},
+		SensitivityFCFMargin: {Type: DistNormal, Mean: base.FCFMargin, StdDev: 0.02,
+			Min: floatPtr(0), Max: floatPtr(1)},
+		SensitivityDiscountRate: {Type: DistNormal, Mean: base.DiscountRate, StdDev: 0.01},
+		SensitivityTerminalGrowth: {Type: DistTriangular, Min: floatPtr(base.TerminalGrowthRate - 0.01),
+			Mode: floatPtr(base.TerminalGrowthRate), Max: floatPtr(base.TerminalGrowthRate + 0.005)},
+	}
+}
+
+// sample draws one value from a distribution
+func sample(rng *rand.Rand, dist finance.Distribution) float64 {
+	switch dist.Type {
+	case DistTriangular:
+		// Inverse CDF of the triangular distribution
+		a, c, b := *dist.Min, *dist.Mode, *dist.Max
+		u := rng.Float64()
+		if u < (c-a)/(b-a) {
+			return a + math.Sqrt(u*(b-a)*(c-a))
+		}
+		return b - math.Sqrt((1-u)*(b-a)*(b-c))
+	case DistUniform:
+		return *dist.Min + rng.Float64()*(*dist.Max-*dist.Min)
+	default:
+		// Truncated normal: redraw outside the bounds, clamp as a last resort
+		var v float64
+		for i := 0; i < maxTruncatedRedraws; i++ {
+			v = dist.Mean + rng.NormFloat64()*dist.StdDev
+			if (dist.Min == nil || v >= *dist.Min) && (dist.Max == nil || v <= *dist.Max) {
+				return v
+			}
+		}
+		if dist.Min != nil && v < *dist.Min {
+			return *dist.Min
+		}
+		if dist.Max != nil && v > *dist.Max {
+			return *dist.Max
+		}
+		return v
+	}
+}
+
+// percentile returns the p-th percentile (0-1) of sorted values using linear interpolation
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	weight := rank - float64(lower)
+	return sorted[lower]*(1-weight) + sorted[upper]*weight
+}
+
+// histogram buckets sorted values into equal-width bins between the min and max
+func histogram(sorted []float64, bins int) []finance.HistogramBin {
+	low, high := sorted[0], sorted[len(sorted)-1]
+	if high == low {
+		return []finance.HistogramBin{{Lower: low, Upper: high, Count: len(sorted)}}
+	}
+
+	width := (high - low) / float64(bins)
+	result := make([]finance.HistogramBin, bins)
+	for i := range result {
+		result[i].Lower = low + float64(i)*width
+		result[i].Upper = low + float64(i+1)*width
+	}
+
+	for _, v := range sorted {
+		idx := int((v - low) / width)
+		if idx >= bins {
+			idx = bins - 1 // The max lands on the last bin's upper edge
+		}
+		result[idx].Count++
+	}
+
+	return result
+}
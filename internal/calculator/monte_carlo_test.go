@@ -0,0 +1,70 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// monteCarloCompany is a minimal company with enough data for a DCF
+func monteCarloCompany() *finance.CompanyData {
+	return &finance.CompanyData{
+		Ticker:            "TEST",
+		SharesOutstanding: 1000, // Millions
+		Quote:             &finance.StockQuote{CurrentPrice: 50},
+		LatestFinancials: &finance.FinancialStatement{
+			Revenue:            10_000_000_000,
+			NetIncome:          1_500_000_000,
+			TotalAssets:        20_000_000_000,
+			TotalDebt:          2_000_000_000,
+			ShareholdersEquity: 10_000_000_000,
+			Cash:               1_000_000_000,
+			OperatingCashFlow:  2_000_000_000,
+			CapEx:              500_000_000,
+			FreeCashFlow:       1_500_000_000,
+		},
+	}
+}
+
+func monteCarloInput(seed int64) MonteCarloInput {
+	return MonteCarloInput{
+		Base:        &DCFInput{DiscountRate: floatPtr(0.09), TerminalGrowthRate: floatPtr(0.025)},
+		Simulations: 500,
+		Seed:        seed,
+	}
+}
+
+func TestCalculateMonteCarloSameSeedIsReproducible(t *testing.T) {
+	first, err := CalculateMonteCarlo(monteCarloCompany(), monteCarloInput(DefaultSeed))
+	if err != nil {
+		t.Fatalf("CalculateMonteCarlo: %v", err)
+	}
+	second, err := CalculateMonteCarlo(monteCarloCompany(), monteCarloInput(DefaultSeed))
+	if err != nil {
+		t.Fatalf("CalculateMonteCarlo: %v", err)
+	}
+
+	if first.MonteCarlo.Percentiles != second.MonteCarlo.Percentiles {
+		t.Errorf("same seed gave different percentiles: %+v vs %+v",
+			first.MonteCarlo.Percentiles, second.MonteCarlo.Percentiles)
+	}
+	if first.MonteCarlo.ValidSimulations != second.MonteCarlo.ValidSimulations {
+		t.Errorf("same seed gave different valid simulation counts: %d vs %d",
+			first.MonteCarlo.ValidSimulations, second.MonteCarlo.ValidSimulations)
+	}
+}
+
+func TestCalculateMonteCarloDifferentSeedDiffers(t *testing.T) {
+	first, err := CalculateMonteCarlo(monteCarloCompany(), monteCarloInput(1))
+	if err != nil {
+		t.Fatalf("CalculateMonteCarlo: %v", err)
+	}
+	second, err := CalculateMonteCarlo(monteCarloCompany(), monteCarloInput(2))
+	if err != nil {
+		t.Fatalf("CalculateMonteCarlo: %v", err)
+	}
+
+	if first.MonteCarlo.Percentiles == second.MonteCarlo.Percentiles {
+		t.Errorf("different seeds gave identical percentiles: %+v", first.MonteCarlo.Percentiles)
+	}
+}
@@ -0,0 +1,142 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// Piotroski test categories
+const (
+	piotroskiProfitability       = "profitability"
+	piotroskiLeverageLiquidity   = "leverage_liquidity"
+	piotroskiOperatingEfficiency = "operating_efficiency"
+)
+
+// shareIssuanceTolerance absorbs rounding in share counts derived from net income / EPS
+const shareIssuanceTolerance = 0.01
+
+// CalculatePiotroski scores the nine binary Piotroski F-Score tests from the two
+// most recent annual statements. Ratios use year-end total assets and total debt
+// (long-term plus short-term). Returns nil with fewer than two annual statements,
+// and an unavailable score when the two are not consecutive fiscal years.
+func CalculatePiotroski(companyData *finance.CompanyData) *finance.PiotroskiScore {
+	if companyData.HistoricalData == nil || len(companyData.HistoricalData.AnnualStatements) < 2 {
+		return nil
+	}
+
+	annual := companyData.HistoricalData.AnnualStatements
+	current := annual[len(annual)-1]
+	prior := annual[len(annual)-2]
+
+	score := &finance.PiotroskiScore{
+		CurrentYear: current.FiscalYear,
+		PriorYear:   prior.FiscalYear,
+	}
+
+	if current.FiscalYear != prior.FiscalYear+1 {
+		score.Rating = finance.RatingNA
+		score.Tests = []finance.PiotroskiTest{}
+		score.Message = fmt.Sprintf("Annual statements for FY%d and FY%d are not consecutive", prior.FiscalYear, current.FiscalYear)
+		return score
+	}
+
+	add := func(name, category, description string, available, passed bool, inputs map[string]float64) {
+		score.Tests = append(score.Tests, finance.PiotroskiTest{
+			Name:        name,
+			Category:    category,
+			Description: description,
+			Available:   available,
+			Passed:      available && passed,
+			Inputs:      inputs,
+		})
+	}
+
+	roa := ratio(current.NetIncome, current.TotalAssets)
+	priorROA := ratio(prior.NetIncome, prior.TotalAssets)
+
+	// Profitability
+	add("positive_roa", piotroskiProfitability, "Return on assets is positive",
+		current.TotalAssets > 0, roa > 0,
+		map[string]float64{"net_income": current.NetIncome, "total_assets": current.TotalAssets, "roa": roa})
+
+	add("positive_operating_cash_flow", piotroskiProfitability, "Operating cash flow is positive",
+		current.OperatingCashFlow != 0, current.OperatingCashFlow > 0,
+		map[string]float64{"operating_cash_flow": current.OperatingCashFlow})
+
+	add("improving_roa", piotroskiProfitability, "Return on assets rose year over year",
+		current.TotalAssets > 0 && prior.TotalAssets > 0, roa > priorROA,
+		map[string]float64{"roa": roa, "prior_roa": priorROA})
+
+	add("cash_flow_exceeds_net_income", piotroskiProfitability, "Operating cash flow exceeds net income (low accruals)",
+		current.OperatingCashFlow != 0, current.OperatingCashFlow > current.NetIncome,
+		map[string]float64{"operating_cash_flow": current.OperatingCashFlow, "net_income": current.NetIncome})
+
+	// Leverage, liquidity and source of funds
+	leverage := ratio(current.TotalDebt, current.TotalAssets)
+	priorLeverage := ratio(prior.TotalDebt, prior.TotalAssets)
+	add("lower_leverage", piotroskiLeverageLiquidity, "Debt to total assets fell year over year",
+		current.TotalAssets > 0 && prior.TotalAssets > 0, leverage < priorLeverage || (leverage == 0 && priorLeverage == 0),
+		map[string]float64{"debt_to_assets": leverage, "prior_debt_to_assets": priorLeverage})
+
+	currentRatio := ratio(current.CurrentAssets, current.CurrentLiabilities)
+	priorCurrentRatio := ratio(prior.CurrentAssets, prior.CurrentLiabilities)
+	add("higher_current_ratio", piotroskiLeverageLiquidity, "Current ratio rose year over year",
+		current.CurrentLiabilities > 0 && prior.CurrentLiabilities > 0, currentRatio > priorCurrentRatio,
+		map[string]float64{"current_ratio": currentRatio, "prior_current_ratio": priorCurrentRatio})
+
+	shares := historicalShares(current, companyData.SharesOutstanding)
+	priorShares := historicalShares(prior, companyData.SharesOutstanding)
+	add("no_share_dilution", piotroskiLeverageLiquidity, "No new shares issued (diluted share count did not grow)",
+		current.EPS != 0 && prior.EPS != 0, shares <= priorShares*(1+shareIssuanceTolerance),
+		map[string]float64{"shares": shares, "prior_shares": priorShares})
+
+	// Operating efficiency
+	grossMargin := ratio(current.GrossProfit, current.Revenue)
+	priorGrossMargin := ratio(prior.GrossProfit, prior.Revenue)
+	add("higher_gross_margin", piotroskiOperatingEfficiency, "Gross margin rose year over year",
+		current.GrossProfit != 0 && prior.GrossProfit != 0 && current.Revenue > 0 && prior.Revenue > 0,
+		grossMargin > priorGrossMargin,
+		map[string]float64{"gross_margin": grossMargin, "prior_gross_margin": priorGrossMargin})
+
+	assetTurnover := ratio(current.Revenue, current.TotalAssets)
+	priorAssetTurnover := ratio(prior.Revenue, prior.TotalAssets)
+	add("higher_asset_turnover", piotroskiOperatingEfficiency, "Asset turnover (revenue / total assets) rose year over year",
+		current.TotalAssets > 0 && prior.TotalAssets > 0, assetTurnover > priorAssetTurnover,
+		map[string]float64{"asset_turnover": assetTurnover, "prior_asset_turnover": priorAssetTurnover})
+
+	for _, test := range score.Tests {
+		if test.Available {
+			score.AvailableTests++
+		}
+		if test.Passed {
+			score.Score++
+		}
+	}
+
+	// Rating logic (8-9 strong, 5-7 average, 0-4 weak)
+	switch {
+	case score.Score >= 8:
+		score.Rating = finance.RatingGreen
+		score.Message = fmt.Sprintf("F-Score of %d/9 indicates strong and improving financials", score.Score)
+	case score.Score >= 5:
+		score.Rating = finance.RatingYellow
+		score.Message = fmt.Sprintf("F-Score of %d/9 indicates average financial strength", score.Score)
+	default:
+		score.Rating = finance.RatingRed
+		score.Message = fmt.Sprintf("F-Score of %d/9 indicates weak or deteriorating financials", score.Score)
+	}
+	if score.AvailableTests < len(score.Tests) {
+		score.Message += fmt.Sprintf(" (%d of %d tests had data)", score.AvailableTests, len(score.Tests))
+	}
+
+	return score
+}
+
+// ratio divides when the denominator is positive and returns 0 otherwise
+func ratio(numerator, denominator float64) float64 {
+	if denominator <= 0 {
+		return 0
+	}
+	return numerator / denominator
+}
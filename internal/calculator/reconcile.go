@@ -0,0 +1,80 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// reconciliationChecks lists the metrics cross-checked against the data vendor.
+// Tolerances are relative differences that definitions alone can explain: the
+// vendor uses TTM figures and average equity, and counts debt differently.
+var reconciliationChecks = []struct {
+	metric           string
+	tolerancePercent float64
+	reference        func(*finance.ReferenceMetrics) float64
+}{
+	{"pe_ratio", 15, func(r *finance.ReferenceMetrics) float64 { return r.PERatio }},
+	{"roe", 20, func(r *finance.ReferenceMetrics) float64 { return r.ROE }},
+	{"debt_to_equity", 25, func(r *finance.ReferenceMetrics) float64 { return r.DebtToEquity }},
+}
+
+// ReconcileMetrics compares the scorecard's P/E, ROE and D/E with the vendor's
+// published values. Metrics either side can't provide are skipped.
+func ReconcileMetrics(scorecard *finance.FundamentalScorecard, companyData *finance.CompanyData) []finance.MetricReconciliation {
+	reference := companyData.ReferenceMetrics
+	if reference == nil {
+		return nil
+	}
+
+	var results []finance.MetricReconciliation
+	for _, check := range reconciliationChecks {
+		metric := scorecardMetrics[check.metric].lookup(scorecard)
+		theirs := check.reference(reference)
+		if metric == nil || !metric.Available || theirs == 0 {
+			continue
+		}
+
+		difference := (metric.Current - theirs) / math.Abs(theirs) * 100
+		result := finance.MetricReconciliation{
+			Metric:            check.metric,
+			Ours:              metric.Current,
+			Reference:         theirs,
+			Source:            reference.Source,
+			DifferencePercent: difference,
+			TolerancePercent:  check.tolerancePercent,
+			Diverges:          math.Abs(difference) > check.tolerancePercent,
+		}
+
+		label := scorecardMetrics[check.metric].label
+		if result.Diverges {
+			direction := "above"
+			if difference < 0 {
+				direction = "below"
+			}
+			result.Message = fmt.Sprintf("%s of %.2f is %.0f%% %s %s's %.2f (tolerance %.0f%%); treat it with caution",
+				label, metric.Current, math.Abs(difference), direction, reference.Source, theirs, check.tolerancePercent)
+		} else {
+			result.Message = fmt.Sprintf("%s of %.2f agrees with %s's %.2f within %.0f%%",
+				label, metric.Current, reference.Source, theirs, check.tolerancePercent)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// ReconciliationWarnings returns a warning for each metric that diverges from the vendor
+func ReconciliationWarnings(scorecard *finance.FundamentalScorecard) []string {
+	if scorecard == nil {
+		return nil
+	}
+
+	var warnings []string
+	for _, result := range scorecard.Reconciliation {
+		if result.Diverges {
+			warnings = append(warnings, result.Message)
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,217 @@
+package calculator
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// Manufacturing SIC range (2000-3999), where the original Altman Z-Score was calibrated
+const (
+	manufacturingSICMin = 2000
+	manufacturingSICMax = 3999
+)
+
+// Altman zone cutoffs for the original and non-manufacturer (Z-double-prime) models
+const (
+	altmanSafe             = 2.99
+	altmanDistress         = 1.81
+	altmanNonMfgSafe       = 2.60
+	altmanNonMfgDistress   = 1.10
+	altmanVariantOriginal  = "original"
+	altmanVariantNonMfg    = "non_manufacturer"
+	beneishLikelyThreshold = -1.78
+	beneishGreyThreshold   = -2.22
+)
+
+// CalculateRedFlags runs the Altman Z-Score and Beneish M-Score screens
+func CalculateRedFlags(companyData *finance.CompanyData) []finance.RedFlag {
+	return []finance.RedFlag{
+		CalculateAltmanZ(companyData),
+		CalculateBeneishM(companyData),
+	}
+}
+
+// CalculateAltmanZ scores bankruptcy risk from the latest balance sheet and income
+// statement. Manufacturers (and companies with no SIC code) use the original
+// model with market value of equity; other industries use the Z-double-prime model, which
+// drops the sales term and uses book equity. Financial institutions are skipped.
+func CalculateAltmanZ(companyData *finance.CompanyData) finance.RedFlag {
+	flag := finance.RedFlag{
+		Name:   "altman_z_score",
+		Rating: finance.RatingNA,
+	}
+
+	financials := companyData.LatestFinancials
+	if financials == nil {
+		flag.Message = "No financial data available"
+		return flag
+	}
+	if IsFinancialInstitution(companyData.SIC) {
+		flag.Message = "Altman Z-Score is not meaningful for banks, lenders and insurers"
+		return flag
+	}
+	if financials.TotalAssets <= 0 || financials.TotalLiabilities <= 0 {
+		flag.Message = "Total assets and total liabilities are required"
+		return flag
+	}
+	if financials.CurrentAssets == 0 || financials.CurrentLiabilities == 0 || financials.OperatingIncome == 0 {
+		flag.Message = "Working capital or EBIT not reported"
+		return flag
+	}
+
+	assets := financials.TotalAssets
+	workingCapital := (financials.CurrentAssets - financials.CurrentLiabilities) / assets
+	retainedEarnings := financials.RetainedEarnings / assets
+	ebit := financials.OperatingIncome / assets
+
+	safe, distress := altmanSafe, altmanDistress
+	if isManufacturer(companyData.SIC) {
+		if companyData.Quote == nil || companyData.Quote.MarketCap <= 0 {
+			flag.Message = "Market cap not available"
+			return flag
+		}
+		marketEquity := companyData.Quote.MarketCap / financials.TotalLiabilities
+		sales := financials.Revenue / assets
+
+		// Z = 1.2·X1 + 1.4·X2 + 3.3·X3 + 0.6·X4 + 1.0·X5
+		flag.Variant = altmanVariantOriginal
+		flag.Score = 1.2*workingCapital + 1.4*retainedEarnings + 3.3*ebit + 0.6*marketEquity + 1.0*sales
+		flag.Components = map[string]float64{
+			"working_capital_to_assets":    workingCapital,
+			"retained_earnings_to_assets":  retainedEarnings,
+			"ebit_to_assets":               ebit,
+			"market_equity_to_liabilities": marketEquity,
+			"sales_to_assets":              sales,
+		}
+	} else {
+		bookEquity := financials.ShareholdersEquity / financials.TotalLiabilities
+
+		// Z'' = 6.56·X1 + 3.26·X2 + 6.72·X3 + 1.05·X4
+		flag.Variant = altmanVariantNonMfg
+		flag.Score = 6.56*workingCapital + 3.26*retainedEarnings + 6.72*ebit + 1.05*bookEquity
+		flag.Components = map[string]float64{
+			"working_capital_to_assets":   workingCapital,
+			"retained_earnings_to_assets": retainedEarnings,
+			"ebit_to_assets":              ebit,
+			"book_equity_to_liabilities":  bookEquity,
+		}
+		safe, distress = altmanNonMfgSafe, altmanNonMfgDistress
+	}
+	flag.Available = true
+
+	switch {
+	case flag.Score > safe:
+		flag.Zone = finance.ZoneSafe
+		flag.Rating = finance.RatingGreen
+		flag.Message = fmt.Sprintf("Z-Score of %.2f is in the safe zone (above %.2f)", flag.Score, safe)
+	case flag.Score >= distress:
+		flag.Zone = finance.ZoneGrey
+		flag.Rating = finance.RatingYellow
+		flag.Message = fmt.Sprintf("Z-Score of %.2f is in the grey zone (%.2f-%.2f) - monitor balance sheet", flag.Score, distress, safe)
+	default:
+		flag.Zone = finance.ZoneDistress
+		flag.Rating = finance.RatingRed
+		flag.Flagged = true
+		flag.Message = fmt.Sprintf("Z-Score of %.2f is in the distress zone (below %.2f) - elevated bankruptcy risk", flag.Score, distress)
+	}
+
+	return flag
+}
+
+// CalculateBeneishM scores the likelihood of earnings manipulation with the
+// eight-variable Beneish model, comparing the two most recent annual statements,
+// which must be consecutive fiscal years.
+// Net income stands in for income from continuing operations.
+func CalculateBeneishM(companyData *finance.CompanyData) finance.RedFlag {
+	flag := finance.RedFlag{
+		Name:   "beneish_m_score",
+		Rating: finance.RatingNA,
+	}
+
+	if companyData.HistoricalData == nil || len(companyData.HistoricalData.AnnualStatements) < 2 {
+		flag.Message = "Two annual statements are required"
+		return flag
+	}
+
+	annual := companyData.HistoricalData.AnnualStatements
+	current := annual[len(annual)-1]
+	prior := annual[len(annual)-2]
+
+	if current.FiscalYear != prior.FiscalYear+1 {
+		flag.Message = fmt.Sprintf("Annual statements for FY%d and FY%d are not consecutive", prior.FiscalYear, current.FiscalYear)
+		return flag
+	}
+
+	for _, s := range []finance.FinancialStatement{current, prior} {
+		if s.Revenue <= 0 || s.TotalAssets <= 0 || s.GrossProfit <= 0 || s.AccountsReceivable <= 0 ||
+			s.PropertyPlantEquipment <= 0 || s.DepreciationAmortization <= 0 || s.SGAExpense <= 0 {
+			flag.Message = fmt.Sprintf("FY%d is missing receivables, gross profit, PP&E, depreciation or SG&A", s.FiscalYear)
+			return flag
+		}
+	}
+
+	// Days sales in receivables index
+	dsri := ratio(current.AccountsReceivable/current.Revenue, prior.AccountsReceivable/prior.Revenue)
+	// Gross margin index (> 1 means margins deteriorated)
+	gmi := ratio(prior.GrossProfit/prior.Revenue, current.GrossProfit/current.Revenue)
+	// Asset quality index: share of assets that are neither current nor PP&E
+	aqi := ratio(1-(current.CurrentAssets+current.PropertyPlantEquipment)/current.TotalAssets,
+		1-(prior.CurrentAssets+prior.PropertyPlantEquipment)/prior.TotalAssets)
+	// Sales growth index
+	sgi := current.Revenue / prior.Revenue
+	// Depreciation index (> 1 means depreciation slowed)
+	depi := ratio(prior.DepreciationAmortization/(prior.DepreciationAmortization+prior.PropertyPlantEquipment),
+		current.DepreciationAmortization/(current.DepreciationAmortization+current.PropertyPlantEquipment))
+	// SG&A index
+	sgai := ratio(current.SGAExpense/current.Revenue, prior.SGAExpense/prior.Revenue)
+	// Leverage index: current liabilities already include current debt, so only
+	// long-term debt is added
+	lvgi := ratio((current.CurrentLiabilities+current.LongTermDebt)/current.TotalAssets,
+		(prior.CurrentLiabilities+prior.LongTermDebt)/prior.TotalAssets)
+	// Total accruals to total assets
+	tata := (current.NetIncome - current.OperatingCashFlow) / current.TotalAssets
+
+	flag.Score = -4.84 + 0.920*dsri + 0.528*gmi + 0.404*aqi + 0.892*sgi + 0.115*depi -
+		0.172*sgai + 4.679*tata - 0.327*lvgi
+	flag.Components = map[string]float64{
+		"dsri": dsri,
+		"gmi":  gmi,
+		"aqi":  aqi,
+		"sgi":  sgi,
+		"depi": depi,
+		"sgai": sgai,
+		"lvgi": lvgi,
+		"tata": tata,
+	}
+	flag.Available = true
+
+	switch {
+	case flag.Score > beneishLikelyThreshold:
+		flag.Zone = finance.ZoneLikelyManipulator
+		flag.Rating = finance.RatingRed
+		flag.Flagged = true
+		flag.Message = fmt.Sprintf("M-Score of %.2f is above %.2f - earnings may be manipulated, review accruals and receivables", flag.Score, beneishLikelyThreshold)
+	case flag.Score > beneishGreyThreshold:
+		flag.Zone = finance.ZoneGrey
+		flag.Rating = finance.RatingYellow
+		flag.Message = fmt.Sprintf("M-Score of %.2f is in the grey zone (%.2f to %.2f)", flag.Score, beneishGreyThreshold, beneishLikelyThreshold)
+	default:
+		flag.Zone = finance.ZoneUnlikelyManipulator
+		flag.Rating = finance.RatingGreen
+		flag.Message = fmt.Sprintf("M-Score of %.2f is below %.2f - manipulation unlikely", flag.Score, beneishGreyThreshold)
+	}
+
+	return flag
+}
+
+// isManufacturer reports whether a SIC code is in the manufacturing division.
+// An unknown SIC code defaults to the original Altman model.
+func isManufacturer(sic string) bool {
+	code, err := strconv.Atoi(sic)
+	if err != nil {
+		return true
+	}
+	return code >= manufacturingSICMin && code <= manufacturingSICMax
+}
@@ -0,0 +1,162 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/sshetty/finEdSkywalker/internal/config"
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// defaultROEFadeYears is how long ROE takes to converge to the cost of equity
+const defaultROEFadeYears = 10
+
+// Financial institution SIC ranges: depository institutions, non-depository credit,
+// brokers and dealers, and insurance carriers and agents (6000-6499)
+const (
+	financialSICMin = 6000
+	financialSICMax = 6499
+)
+
+// ResidualIncomeInput represents user-provided or default inputs for the residual income model
+type ResidualIncomeInput struct {
+	CostOfEquity *float64 // Optional: defaults to CAPM
+	ROE          *float64 // Optional: starting ROE (default: latest net income / equity)
+	FadeYears    *int     // Optional: years for ROE to fade to the cost of equity (default 10)
+
+	// CAPM overrides used when CostOfEquity is not supplied
+	RiskFreeRate      *float64
+	EquityRiskPremium *float64
+}
+
+// IsFinancialInstitution reports whether a SIC code belongs to a bank, lender,
+// broker-dealer or insurer, where book value drives valuation rather than cash flow
+func IsFinancialInstitution(sic string) bool {
+	code, err := strconv.Atoi(sic)
+	if err != nil {
+		return false
+	}
+	return code >= financialSICMin && code <= financialSICMax
+}
+
+// CalculateResidualIncome values equity as book value plus the present value of
+// future residual income: RI(t) = (ROE(t) - ke) × Book Value(t-1).
+// ROE fades linearly to the cost of equity, so residual income is zero after the
+// fade and no terminal value is needed.
+func CalculateResidualIncome(companyData *finance.CompanyData, input *ResidualIncomeInput) (*finance.ValuationResult, error) {
+	financials := companyData.LatestFinancials
+	if financials == nil {
+		return nil, fmt.Errorf("no financial data available for residual income calculation")
+	}
+	if companyData.SharesOutstanding <= 0 {
+		return nil, fmt.Errorf("shares outstanding not available")
+	}
+	if financials.ShareholdersEquity <= 0 {
+		return nil, fmt.Errorf("positive shareholders' equity is required for the residual income model")
+	}
+
+	if input == nil {
+		input = &ResidualIncomeInput{}
+	}
+
+	shares := companyData.SharesOutstanding * 1_000_000 // Shares in millions
+	ri := &finance.ResidualIncomeResult{
+		BookValuePerShare: financials.ShareholdersEquity / shares,
+		FadeYears:         defaultROEFadeYears,
+	}
+
+	assumptions := finance.DCFAssumptions{
+		Source: "defaults",
+	}
+
+	// Cost of equity: user input, else CAPM
+	if input.CostOfEquity != nil {
+		ri.CostOfEquity = *input.CostOfEquity
+		assumptions.Source = "user_input"
+	} else {
+		cfg := config.GetConfig()
+		riskFreeRate := cfg.RiskFreeRate
+		equityRiskPremium := cfg.EquityRiskPremium
+		if input.RiskFreeRate != nil {
+			riskFreeRate = *input.RiskFreeRate
+		}
+		if input.EquityRiskPremium != nil {
+			equityRiskPremium = *input.EquityRiskPremium
+		}
+		assumptions.WACC = CalculateWACC(companyData, riskFreeRate, equityRiskPremium)
+		ri.CostOfEquity = assumptions.WACC.CostOfEquity
+	}
+	if ri.CostOfEquity <= 0 {
+		return nil, fmt.Errorf("cost of equity must be positive")
+	}
+
+	if input.ROE != nil {
+		ri.StartingROE = *input.ROE
+		ri.ROESource = "user_input"
+	} else {
+		ri.StartingROE = financials.NetIncome / financials.ShareholdersEquity
+		ri.ROESource = "latest_financials"
+	}
+
+	if input.FadeYears != nil {
+		ri.FadeYears = clampYears(*input.FadeYears, 1)
+	}
+
+	// Retained earnings grow book value; payout comes from dividends per share over EPS
+	if financials.DividendsPerShare > 0 && financials.EPS > 0 {
+		ri.PayoutRatio = math.Min(financials.DividendsPerShare/financials.EPS, 1)
+	}
+
+	bookValue := ri.BookValuePerShare
+	for year := 1; year <= ri.FadeYears; year++ {
+		// Linear fade: the final year's ROE equals the cost of equity
+		roe := fade(ri.StartingROE, ri.CostOfEquity, float64(year)/float64(ri.FadeYears))
+
+		earnings := roe * bookValue
+		residualIncome := (roe - ri.CostOfEquity) * bookValue
+		discountFactor := math.Pow(1+ri.CostOfEquity, float64(year))
+		presentValue := residualIncome / discountFactor
+
+		ri.Projections = append(ri.Projections, finance.ResidualIncomeProjection{
+			Year:               year,
+			ROE:                roe,
+			BeginningBookValue: bookValue,
+			EarningsPerShare:   earnings,
+			ResidualIncome:     residualIncome,
+			DiscountFactor:     discountFactor,
+			PresentValue:       presentValue,
+		})
+		ri.PVResidualIncome += presentValue
+
+		bookValue += earnings * (1 - ri.PayoutRatio)
+	}
+
+	// Value = Book Value per Share + PV of residual income
+	fairValue := ri.BookValuePerShare + ri.PVResidualIncome
+
+	assumptions.DiscountRate = ri.CostOfEquity
+	assumptions.ProjectionYears = ri.FadeYears
+	assumptions.FadeYears = ri.FadeYears
+
+	currentPrice := 0.0
+	if companyData.Quote != nil {
+		currentPrice = companyData.Quote.CurrentPrice
+	}
+
+	upsidePercent := 0.0
+	if currentPrice > 0 {
+		upsidePercent = ((fairValue - currentPrice) / currentPrice) * 100
+	}
+
+	return &finance.ValuationResult{
+		FairValuePerShare: fairValue,
+		CurrentPrice:      currentPrice,
+		UpsidePercent:     upsidePercent,
+		Model:             "Residual Income",
+		Assumptions:       assumptions,
+		EquityValue:       fairValue * shares,
+		SharesOutstanding: companyData.SharesOutstanding,
+		ResidualIncome:    ri,
+	}, nil
+}
@@ -0,0 +1,116 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// Reverse DCF solver settings
+const (
+	impliedGrowthMin       = -0.50 // Search bracket for the implied growth rate
+	impliedGrowthMax       = 1.00
+	impliedGrowthTolerance = 1e-6 // Converged when the bracket is narrower than this
+	priceTolerance         = 0.01 // ...or fair value is within a cent of the price
+	maxSolverIterations    = 100
+)
+
+// CalculateReverseDCF solves for the revenue growth rate the market is pricing in:
+// the growth that makes the DCF fair value per share equal the current price,
+// holding every other assumption fixed. It uses bisection, which always converges
+// once the price is bracketed because fair value rises monotonically with growth
+// when FCF margins are positive.
+func CalculateReverseDCF(companyData *finance.CompanyData, input *DCFInput) (*finance.ValuationResult, error) {
+	if companyData.Quote == nil || companyData.Quote.CurrentPrice <= 0 {
+		return nil, fmt.Errorf("current price is required for a reverse DCF")
+	}
+	targetPrice := companyData.Quote.CurrentPrice
+
+	if input == nil {
+		input = &DCFInput{}
+	}
+
+	// Base case: resolves every other assumption (including the WACC) once
+	base, err := CalculateDCF(companyData, input)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned := *input
+	pinned.ProfitMargin = floatPtr(base.Assumptions.ProfitMargin)
+	pinned.FCFMargin = floatPtr(base.Assumptions.FCFMargin)
+	pinned.DiscountRate = floatPtr(base.Assumptions.DiscountRate)
+	pinned.TerminalGrowthRate = floatPtr(base.Assumptions.TerminalGrowthRate)
+
+	// residual is fair value minus price at a given growth rate
+	residual := func(growth float64) (float64, *finance.ValuationResult, error) {
+		trial := pinned
+		trial.RevenueGrowthRate = floatPtr(growth)
+		valuation, err := CalculateDCF(companyData, &trial)
+		if err != nil {
+			return 0, nil, err
+		}
+		return valuation.FairValuePerShare - targetPrice, valuation, nil
+	}
+
+	low, high := impliedGrowthMin, impliedGrowthMax
+	lowResidual, _, err := residual(low)
+	if err != nil {
+		return nil, err
+	}
+	highResidual, _, err := residual(high)
+	if err != nil {
+		return nil, err
+	}
+
+	if lowResidual*highResidual > 0 {
+		return nil, fmt.Errorf("current price $%.2f is not reachable with revenue growth between %.0f%% and %.0f%% under these assumptions",
+			targetPrice, impliedGrowthMin*100, impliedGrowthMax*100)
+	}
+
+	diagnostics := finance.SolverDiagnostics{
+		Method:      "bisection",
+		BracketLow:  impliedGrowthMin,
+		BracketHigh: impliedGrowthMax,
+		Tolerance:   impliedGrowthTolerance,
+	}
+
+	var mid float64
+	var midResidual float64
+	var valuation *finance.ValuationResult
+	for diagnostics.Iterations < maxSolverIterations {
+		diagnostics.Iterations++
+		mid = (low + high) / 2
+
+		midResidual, valuation, err = residual(mid)
+		if err != nil {
+			return nil, err
+		}
+
+		if math.Abs(midResidual) < priceTolerance || (high-low)/2 < impliedGrowthTolerance {
+			diagnostics.Converged = true
+			break
+		}
+
+		// Keep the half of the bracket where the residual changes sign
+		if lowResidual*midResidual < 0 {
+			high = mid
+		} else {
+			low, lowResidual = mid, midResidual
+		}
+	}
+	diagnostics.Residual = midResidual
+
+	valuation.Model = "Reverse DCF"
+	valuation.Assumptions.Source = "implied_by_market_price"
+	valuation.ReverseDCF = &finance.ReverseDCFResult{
+		ImpliedGrowthRate:  mid,
+		BaseCaseGrowthRate: base.Assumptions.RevenueGrowthRate,
+		BaseCaseFairValue:  base.FairValuePerShare,
+		GrowthGap:          mid - base.Assumptions.RevenueGrowthRate,
+		Diagnostics:        diagnostics,
+	}
+
+	return valuation, nil
+}
@@ -0,0 +1,184 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/sshetty/finEdSkywalker/internal/config"
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// DefaultRiskBenchmark is the ticker beta is measured against
+const DefaultRiskBenchmark = "SPY"
+
+// Trailing windows for risk statistics, in years
+var riskWindowYears = []int{1, 3, maxRiskWindowYears}
+
+const (
+	maxRiskWindowYears  = 5
+	tradingDaysPerYear  = 252
+	riskWindowSlackDays = 10 // History may start this many days after a window's start (holidays)
+	minBetaObservations = 20
+)
+
+// CalculateRiskStatistics computes return, volatility, drawdown, Sharpe, Sortino
+// and beta for 1, 3 and 5 year windows ending at the latest candle. Both price
+// series are ascending daily candles; benchmarkPrices may be empty, which omits beta.
+// riskFreeRate defaults to config.
+func CalculateRiskStatistics(prices, benchmarkPrices []finance.PriceCandle, benchmark string, riskFreeRate *float64) *finance.RiskStatistics {
+	rf := config.GetConfig().RiskFreeRate
+	if riskFreeRate != nil {
+		rf = *riskFreeRate
+	}
+
+	stats := &finance.RiskStatistics{
+		Benchmark:    benchmark,
+		RiskFreeRate: rf,
+		Windows:      make([]finance.RiskWindow, 0, len(riskWindowYears)),
+	}
+
+	benchmarkCloses := make(map[string]float64, len(benchmarkPrices))
+	for _, candle := range benchmarkPrices {
+		benchmarkCloses[candle.Date.Format("2006-01-02")] = candle.Close
+	}
+
+	for _, years := range riskWindowYears {
+		stats.Windows = append(stats.Windows, calculateRiskWindow(prices, benchmarkCloses, years, rf))
+	}
+	return stats
+}
+
+// calculateRiskWindow computes the statistics for the trailing window of the given years
+func calculateRiskWindow(prices []finance.PriceCandle, benchmarkCloses map[string]float64, years int, rf float64) finance.RiskWindow {
+	window := finance.RiskWindow{Period: fmt.Sprintf("%dy", years)}
+	if len(prices) < 2 {
+		window.Message = "Not enough price history"
+		return window
+	}
+
+	end := prices[len(prices)-1].Date
+	start := end.AddDate(-years, 0, 0)
+	if prices[0].Date.After(start.AddDate(0, 0, riskWindowSlackDays)) {
+		window.Message = fmt.Sprintf("Price history starts %s, less than %d years ago", prices[0].Date.Format("2006-01-02"), years)
+		return window
+	}
+
+	// Start from the last close on or before the window start so the return covers the whole period
+	first := sort.Search(len(prices), func(i int) bool { return prices[i].Date.After(start) })
+	if first > 0 {
+		first--
+	}
+	candles := prices[first:]
+
+	closes := make([]float64, len(candles))
+	for i, candle := range candles {
+		closes[i] = candle.Close
+	}
+	returns := dailyReturns(closes)
+	if len(returns) < 2 || closes[0] <= 0 {
+		window.Message = "Not enough price history"
+		return window
+	}
+
+	elapsedYears := candles[len(candles)-1].Date.Sub(candles[0].Date).Hours() / 24 / 365.25
+	window.StartDate = candles[0].Date.Format("2006-01-02")
+	window.EndDate = end.Format("2006-01-02")
+	window.TradingDays = len(returns)
+	window.AnnualizedReturn = math.Pow(closes[len(closes)-1]/closes[0], 1/elapsedYears) - 1
+	window.AnnualizedVolatility = stdDev(returns) * math.Sqrt(tradingDaysPerYear)
+	window.MaxDrawdown = maxDrawdown(closes)
+	window.Available = true
+
+	// Sharpe and Sortino use the arithmetic convention (Sharpe, 1994): the mean daily
+	// return in excess of the daily risk-free rate, annualized × 252, over the
+	// annualized standard deviation or downside deviation of the same daily returns
+	dailyRiskFree := rf / tradingDaysPerYear
+	excessReturn := (mean(returns) - dailyRiskFree) * tradingDaysPerYear
+	if window.AnnualizedVolatility > 0 {
+		window.SharpeRatio = floatPtr(excessReturn / window.AnnualizedVolatility)
+	}
+	if downside := downsideDeviation(returns, dailyRiskFree); downside > 0 {
+		window.SortinoRatio = floatPtr(excessReturn / downside)
+	}
+	window.Beta = calculateBeta(candles, benchmarkCloses)
+
+	return window
+}
+
+// dailyReturns converts closes into simple day-over-day returns
+func dailyReturns(closes []float64) []float64 {
+	returns := make([]float64, 0, len(closes))
+	for i := 1; i < len(closes); i++ {
+		if closes[i-1] > 0 {
+			returns = append(returns, closes[i]/closes[i-1]-1)
+		}
+	}
+	return returns
+}
+
+// maxDrawdown is the largest peak-to-trough decline as a negative fraction
+func maxDrawdown(closes []float64) float64 {
+	peak := closes[0]
+	worst := 0.0
+	for _, c := range closes {
+		peak = math.Max(peak, c)
+		if peak > 0 {
+			worst = math.Min(worst, c/peak-1)
+		}
+	}
+	return worst
+}
+
+// downsideDeviation annualizes the root mean square of daily returns below the daily target
+func downsideDeviation(returns []float64, target float64) float64 {
+	sumSquares := 0.0
+	for _, r := range returns {
+		if shortfall := r - target; shortfall < 0 {
+			sumSquares += shortfall * shortfall
+		}
+	}
+	return math.Sqrt(sumSquares/float64(len(returns))) * math.Sqrt(tradingDaysPerYear)
+}
+
+// calculateBeta is cov(stock, benchmark) / var(benchmark) over daily returns on
+// dates both series traded. Returns nil without enough overlapping days.
+func calculateBeta(candles []finance.PriceCandle, benchmarkCloses map[string]float64) *float64 {
+	if len(benchmarkCloses) == 0 {
+		return nil
+	}
+
+	var stockReturns, benchmarkReturns []float64
+	prevStock, prevBenchmark := 0.0, 0.0
+	for _, candle := range candles {
+		benchmarkClose, ok := benchmarkCloses[candle.Date.Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		if prevStock > 0 && prevBenchmark > 0 {
+			stockReturns = append(stockReturns, candle.Close/prevStock-1)
+			benchmarkReturns = append(benchmarkReturns, benchmarkClose/prevBenchmark-1)
+		}
+		prevStock, prevBenchmark = candle.Close, benchmarkClose
+	}
+	if len(stockReturns) < minBetaObservations {
+		return nil
+	}
+
+	stockMean, benchmarkMean := mean(stockReturns), mean(benchmarkReturns)
+	covariance, variance := 0.0, 0.0
+	for i := range stockReturns {
+		covariance += (stockReturns[i] - stockMean) * (benchmarkReturns[i] - benchmarkMean)
+		variance += (benchmarkReturns[i] - benchmarkMean) * (benchmarkReturns[i] - benchmarkMean)
+	}
+	if variance == 0 {
+		return nil
+	}
+	return floatPtr(covariance / variance)
+}
+
+// RiskHistoryStart is the earliest daily candle CalculateRiskStatistics needs for windows ending at end
+func RiskHistoryStart(end time.Time) time.Time {
+	return end.AddDate(-maxRiskWindowYears, 0, -riskWindowSlackDays)
+}
@@ -0,0 +1,168 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// Moat signal: ROIC above the threshold in at least moatYearsRequired of the last five years
+const (
+	moatROICThreshold = 15.0 // Percentage
+	moatYearsRequired = 4
+)
+
+// Moat signals
+const (
+	MoatSignalMoat         = "moat"
+	MoatSignalNone         = "no_moat"
+	MoatSignalInsufficient = "insufficient_history"
+)
+
+// CalculateCapitalEfficiency rates ROIC, its spread over WACC and the
+// multi-year moat signal. The WACC uses the input's CAPM overrides, matching
+// the DCF in the same response; a nil input uses the configured defaults.
+// Returns nil without financial data.
+func CalculateCapitalEfficiency(companyData *finance.CompanyData, input *DCFInput) *finance.CapitalEfficiencySection {
+	if companyData.LatestFinancials == nil {
+		return nil
+	}
+
+	section := &finance.CapitalEfficiencySection{
+		ROIC:           calculateROICMetric(companyData),
+		ROICWACCSpread: finance.FundamentalMetric{Rating: finance.RatingNA},
+		Moat:           calculateMoatSignal(companyData),
+	}
+
+	if !section.ROIC.Available {
+		section.ROICWACCSpread.Message = "ROIC not available"
+		return section
+	}
+
+	wacc := calculateInputWACC(companyData, input)
+	section.WACC = wacc.WACC * 100
+
+	// Spread = ROIC - WACC (percentage points); positive means value creation
+	spread := section.ROIC.Current - section.WACC
+	section.ROICWACCSpread.Current = spread
+	section.ROICWACCSpread.Available = true
+
+	switch {
+	case spread > 5:
+		section.ROICWACCSpread.Rating = finance.RatingGreen
+		section.ROICWACCSpread.Message = fmt.Sprintf("ROIC exceeds WACC (%.2f%%) by %.2f points - creating value", section.WACC, spread)
+	case spread > 0:
+		section.ROICWACCSpread.Rating = finance.RatingYellow
+		section.ROICWACCSpread.Message = fmt.Sprintf("ROIC is %.2f points above WACC (%.2f%%) - thin margin of value creation", spread, section.WACC)
+	default:
+		section.ROICWACCSpread.Rating = finance.RatingRed
+		section.ROICWACCSpread.Message = fmt.Sprintf("ROIC is %.2f points below WACC (%.2f%%) - destroying value", -spread, section.WACC)
+	}
+
+	return section
+}
+
+// calculateROICMetric rates the latest ROIC against its five-year average
+func calculateROICMetric(data *finance.CompanyData) finance.FundamentalMetric {
+	metric := finance.FundamentalMetric{
+		Available: false,
+		Rating:    finance.RatingNA,
+	}
+
+	roic, ok := calculateROIC(*data.LatestFinancials)
+	if !ok {
+		metric.Message = "EBIT or positive invested capital not available"
+		return metric
+	}
+
+	metric.Current = roic * 100
+	metric.Available = true
+
+	if data.HistoricalData != nil {
+		metric.FiveYearAvg = averageNonZero(data.HistoricalData.ROICHistory)
+	}
+
+	// Rating logic (higher is better)
+	if metric.Current > moatROICThreshold {
+		metric.Rating = finance.RatingGreen
+		metric.Message = fmt.Sprintf("Excellent ROIC (%.2f%%) - strong returns on capital", metric.Current)
+	} else if metric.Current > 10 {
+		metric.Rating = finance.RatingYellow
+		metric.Message = fmt.Sprintf("Good ROIC (%.2f%%)", metric.Current)
+	} else if metric.Current > 0 {
+		metric.Rating = finance.RatingRed
+		metric.Message = fmt.Sprintf("Low ROIC (%.2f%%) - weak returns on capital", metric.Current)
+	} else {
+		metric.Rating = finance.RatingRed
+		metric.Message = fmt.Sprintf("Negative ROIC (%.2f%%) - operating at a loss", metric.Current)
+	}
+
+	return metric
+}
+
+// calculateMoatSignal checks how many of the last five fiscal years had ROIC above the threshold
+func calculateMoatSignal(data *finance.CompanyData) *finance.MoatSignal {
+	signal := &finance.MoatSignal{
+		Threshold:     moatROICThreshold,
+		YearsRequired: moatYearsRequired,
+		Rating:        finance.RatingNA,
+	}
+
+	if data.HistoricalData != nil {
+		signal.FiscalYears = data.HistoricalData.FiscalYears
+		signal.ROICHistory = data.HistoricalData.ROICHistory
+	}
+
+	for _, roic := range signal.ROICHistory {
+		if roic != 0 {
+			signal.YearsAvailable++
+		}
+		if roic > moatROICThreshold {
+			signal.YearsAbove++
+		}
+	}
+
+	switch {
+	case signal.YearsAbove >= moatYearsRequired:
+		signal.Signal = MoatSignalMoat
+		signal.Rating = finance.RatingGreen
+		signal.Message = fmt.Sprintf("ROIC above %.0f%% in %d of %d years - consistent returns suggest an economic moat",
+			moatROICThreshold, signal.YearsAbove, len(signal.ROICHistory))
+	case signal.YearsAvailable < moatYearsRequired:
+		signal.Signal = MoatSignalInsufficient
+		signal.Message = fmt.Sprintf("ROIC available for %d years; %d are needed for a moat signal",
+			signal.YearsAvailable, moatYearsRequired)
+	default:
+		signal.Signal = MoatSignalNone
+		signal.Rating = finance.RatingRed
+		signal.Message = fmt.Sprintf("ROIC above %.0f%% in only %d of %d years - no consistent moat",
+			moatROICThreshold, signal.YearsAbove, len(signal.ROICHistory))
+	}
+
+	return signal
+}
+
+// calculateROIC returns NOPAT / invested capital as a ratio. NOPAT taxes EBIT at
+// the effective rate (statutory when out of range); invested capital is total
+// debt plus equity less cash, which stays positive for most companies whose
+// buybacks have pushed book equity negative.
+func calculateROIC(statement finance.FinancialStatement) (float64, bool) {
+	if statement.OperatingIncome == 0 {
+		return 0, false
+	}
+
+	investedCapital := statement.TotalDebt + statement.ShareholdersEquity - statement.Cash
+	if investedCapital <= 0 {
+		return 0, false
+	}
+
+	taxRate := statutoryTaxRate
+	if statement.PretaxIncome > 0 {
+		if effective := statement.IncomeTaxExpense / statement.PretaxIncome; effective > 0 && effective < 0.5 {
+			taxRate = effective
+		}
+	}
+
+	nopat := statement.OperatingIncome * (1 - taxRate)
+	return nopat / investedCapital, true
+}
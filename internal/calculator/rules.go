@@ -0,0 +1,299 @@
+package calculator
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+//go:embed scorecard_rules.json
+var scorecardRulesJSON []byte
+
+// Where a rule's rating came from
+const (
+	ThresholdSourceRuleSet = "rule_set" // The rule's own thresholds
+	ThresholdSourceMetric  = "metric"   // The metric's built-in rating (sector profile for the Big 5)
+)
+
+// defaultRatingPoints converts ratings to points when a rule set doesn't define its own
+var defaultRatingPoints = map[finance.MetricRating]float64{
+	finance.RatingGreen:  1,
+	finance.RatingYellow: 0.5,
+	finance.RatingRed:    0,
+}
+
+// RuleSet is a named, weighted selection of scorecard metrics
+type RuleSet struct {
+	Name         string                           `json:"name"`
+	Description  string                           `json:"description"`
+	RatingPoints map[finance.MetricRating]float64 `json:"rating_points,omitempty"`
+	Rules        []ScoringRule                    `json:"rules"`
+}
+
+// ScoringRule weights one metric and optionally overrides its rating thresholds
+type ScoringRule struct {
+	Metric     string   `json:"metric"`
+	Weight     float64  `json:"weight"`
+	Thresholds *Cutoffs `json:"thresholds,omitempty"`
+}
+
+// scorecardMetric describes a metric that rules can reference
+type scorecardMetric struct {
+	label         string
+	lowerIsBetter bool
+	lookup        func(*finance.FundamentalScorecard) *finance.FundamentalMetric
+}
+
+// scorecardMetrics are the metrics available to rule sets, keyed by rule name
+var scorecardMetrics = map[string]scorecardMetric{
+	"pe_ratio": {
+		label:         "P/E",
+		lowerIsBetter: true,
+		lookup:        func(s *finance.FundamentalScorecard) *finance.FundamentalMetric { return &s.PERatio },
+	},
+	"forward_pe": {
+		label:         "Forward P/E",
+		lowerIsBetter: true,
+		lookup:        func(s *finance.FundamentalScorecard) *finance.FundamentalMetric { return &s.ForwardPE },
+	},
+	"debt_to_equity": {
+		label:         "Debt-to-equity",
+		lowerIsBetter: true,
+		lookup:        func(s *finance.FundamentalScorecard) *finance.FundamentalMetric { return &s.DebtToEquity },
+	},
+	"fcf_yield": {
+		label:  "FCF yield",
+		lookup: func(s *finance.FundamentalScorecard) *finance.FundamentalMetric { return &s.FCFYield },
+	},
+	"peg_ratio": {
+		label:         "PEG",
+		lowerIsBetter: true,
+		lookup:        func(s *finance.FundamentalScorecard) *finance.FundamentalMetric { return &s.PEGRatio },
+	},
+	"roe": {
+		label:  "ROE",
+		lookup: func(s *finance.FundamentalScorecard) *finance.FundamentalMetric { return &s.ROE },
+	},
+	"roic": {
+		label: "ROIC",
+		lookup: func(s *finance.FundamentalScorecard) *finance.FundamentalMetric {
+			if s.CapitalEfficiency == nil {
+				return nil
+			}
+			return &s.CapitalEfficiency.ROIC
+		},
+	},
+	"roic_wacc_spread": {
+		label: "ROIC-WACC spread",
+		lookup: func(s *finance.FundamentalScorecard) *finance.FundamentalMetric {
+			if s.CapitalEfficiency == nil {
+				return nil
+			}
+			return &s.CapitalEfficiency.ROICWACCSpread
+		},
+	},
+	"piotroski_f_score": {
+		label: "Piotroski F-Score",
+		lookup: func(s *finance.FundamentalScorecard) *finance.FundamentalMetric {
+			if s.Quality == nil || s.Quality.Piotroski == nil {
+				return nil
+			}
+			p := s.Quality.Piotroski
+			return &finance.FundamentalMetric{
+				Current:   float64(p.Score),
+				Rating:    p.Rating,
+				Message:   p.Message,
+				Available: p.AvailableTests > 0,
+			}
+		},
+	},
+	"altman_z_score": {
+		label: "Altman Z-Score",
+		lookup: func(s *finance.FundamentalScorecard) *finance.FundamentalMetric {
+			for _, flag := range s.RedFlags {
+				if flag.Name == "altman_z_score" {
+					return &finance.FundamentalMetric{
+						Current:   flag.Score,
+						Rating:    flag.Rating,
+						Message:   flag.Message,
+						Available: flag.Available,
+					}
+				}
+			}
+			return nil
+		},
+	},
+}
+
+// builtinRuleSet is used if the embedded rule file fails to parse
+var builtinRuleSet = RuleSet{
+	Name:        "big5",
+	Description: "The Big 5 metrics, equally weighted and rated with the sector threshold profile",
+	Rules: []ScoringRule{
+		{Metric: "pe_ratio", Weight: 1},
+		{Metric: "debt_to_equity", Weight: 1},
+		{Metric: "fcf_yield", Weight: 1},
+		{Metric: "peg_ratio", Weight: 1},
+		{Metric: "roe", Weight: 1},
+	},
+}
+
+var (
+	ruleSets       []RuleSet
+	defaultRuleSet string
+	ruleSetsOnce   sync.Once
+)
+
+// loadRuleSets parses and validates the embedded rule file once per Lambda container
+func loadRuleSets() []RuleSet {
+	ruleSetsOnce.Do(func() {
+		var file struct {
+			Default  string    `json:"default"`
+			RuleSets []RuleSet `json:"rule_sets"`
+		}
+		err := json.Unmarshal(scorecardRulesJSON, &file)
+		for i := 0; err == nil && i < len(file.RuleSets); i++ {
+			err = validateRuleSet(&file.RuleSets[i])
+		}
+		if err != nil {
+			log.Printf("Failed to load scorecard rules, using the Big 5: %v", err)
+			ruleSets = []RuleSet{builtinRuleSet}
+			defaultRuleSet = builtinRuleSet.Name
+			return
+		}
+		ruleSets = file.RuleSets
+		defaultRuleSet = file.Default
+	})
+	return ruleSets
+}
+
+// validateRuleSet checks metric names, weights and threshold ordering
+func validateRuleSet(set *RuleSet) error {
+	if set.Name == "" || len(set.Rules) == 0 {
+		return fmt.Errorf("rule set %q must have a name and at least one rule", set.Name)
+	}
+
+	seen := make(map[string]bool)
+	for _, rule := range set.Rules {
+		metric, ok := scorecardMetrics[rule.Metric]
+		if !ok {
+			return fmt.Errorf("rule set %q: unknown metric %q", set.Name, rule.Metric)
+		}
+		if seen[rule.Metric] {
+			return fmt.Errorf("rule set %q: metric %q is listed twice", set.Name, rule.Metric)
+		}
+		seen[rule.Metric] = true
+
+		if rule.Weight <= 0 {
+			return fmt.Errorf("rule set %q: weight for %q must be positive", set.Name, rule.Metric)
+		}
+		if t := rule.Thresholds; t != nil {
+			if (metric.lowerIsBetter && t.Green > t.Yellow) || (!metric.lowerIsBetter && t.Green < t.Yellow) {
+				return fmt.Errorf("rule set %q: green and yellow thresholds for %q are in the wrong order", set.Name, rule.Metric)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadRuleSet returns the named scorecard rule set, or the default when name is empty
+func LoadRuleSet(name string) (*RuleSet, error) {
+	sets := loadRuleSets()
+	if name == "" {
+		name = defaultRuleSet
+	}
+
+	for i := range sets {
+		if strings.EqualFold(sets[i].Name, name) {
+			return &sets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown rule set %q (available: %s)", name, strings.Join(RuleSetNames(), ", "))
+}
+
+// RuleSetNames lists the available scorecard rule sets
+func RuleSetNames() []string {
+	sets := loadRuleSets()
+	names := make([]string, len(sets))
+	for i, set := range sets {
+		names[i] = set.Name
+	}
+	return names
+}
+
+// applyRuleSet rates the rule set's metrics and computes the weighted score (0-100).
+// Rules with thresholds re-rate their metric in place so the scorecard fields agree.
+func applyRuleSet(scorecard *finance.FundamentalScorecard, set *RuleSet) []finance.RuleResult {
+	points := set.RatingPoints
+	if len(points) == 0 {
+		points = defaultRatingPoints
+	}
+
+	scorecard.RuleSet = set.Name
+	results := make([]finance.RuleResult, 0, len(set.Rules))
+	totalWeight := 0.0
+	weightedPoints := 0.0
+
+	for _, rule := range set.Rules {
+		definition := scorecardMetrics[rule.Metric]
+		result := finance.RuleResult{
+			Metric:          rule.Metric,
+			Weight:          rule.Weight,
+			Rating:          finance.RatingNA,
+			ThresholdSource: ThresholdSourceMetric,
+		}
+
+		metric := definition.lookup(scorecard)
+		if metric != nil && metric.Available {
+			if rule.Thresholds != nil {
+				rateWithThresholds(metric, definition, *rule.Thresholds, set.Name)
+				result.ThresholdSource = ThresholdSourceRuleSet
+			}
+
+			result.Value = metric.Current
+			result.Rating = metric.Rating
+			result.Available = true
+			result.Points = points[metric.Rating]
+
+			totalWeight += rule.Weight
+			weightedPoints += rule.Weight * result.Points
+		}
+
+		results = append(results, result)
+	}
+
+	if totalWeight > 0 {
+		scorecard.WeightedScore = weightedPoints / totalWeight * 100
+	}
+
+	return results
+}
+
+// rateWithThresholds replaces a metric's rating with a rule's cutoffs
+func rateWithThresholds(metric *finance.FundamentalMetric, definition scorecardMetric, cutoffs Cutoffs, ruleSet string) {
+	value := metric.Current
+	green := value > cutoffs.Green
+	yellow := value > cutoffs.Yellow
+	if definition.lowerIsBetter {
+		green = value < cutoffs.Green
+		yellow = value < cutoffs.Yellow
+	}
+
+	metric.Profile = ""
+	switch {
+	case green:
+		metric.Rating = finance.RatingGreen
+		metric.Message = fmt.Sprintf("%s (%.2f) meets the %s rule set's green cutoff (%g)", definition.label, value, ruleSet, cutoffs.Green)
+	case yellow:
+		metric.Rating = finance.RatingYellow
+		metric.Message = fmt.Sprintf("%s (%.2f) meets the %s rule set's yellow cutoff (%g)", definition.label, value, ruleSet, cutoffs.Yellow)
+	default:
+		metric.Rating = finance.RatingRed
+		metric.Message = fmt.Sprintf("%s (%.2f) misses the %s rule set's cutoffs (green %g, yellow %g)", definition.label, value, ruleSet, cutoffs.Green, cutoffs.Yellow)
+	}
+}
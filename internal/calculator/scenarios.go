@@ -0,0 +1,166 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// Default scenario settings
+const (
+	bullBearProbability  = 0.25 // Base case gets the remaining 50%
+	minScenarioHistory   = 3    // Annual statements needed to measure dispersion
+	fallbackGrowthSpread = 0.03 // Used when history is too short
+	fallbackMarginSpread = 0.02
+	minScenarioSpread    = 0.01 // Floor so bull and bear stay distinct from the base case
+)
+
+// ScenarioInput is one named set of DCF inputs with its probability weight
+type ScenarioInput struct {
+	Name        string
+	Probability float64
+	Input       *DCFInput
+}
+
+// CalculateScenarios values each scenario and the probability-weighted fair value.
+// Weights are normalized so they need not sum to exactly 1.
+func CalculateScenarios(companyData *finance.CompanyData, scenarios []ScenarioInput) (*finance.ScenarioAnalysis, error) {
+	if len(scenarios) == 0 {
+		return nil, fmt.Errorf("at least one scenario is required")
+	}
+
+	totalWeight := 0.0
+	for _, scenario := range scenarios {
+		if scenario.Probability < 0 {
+			return nil, fmt.Errorf("scenario %q has a negative probability", scenario.Name)
+		}
+		totalWeight += scenario.Probability
+	}
+	if totalWeight <= 0 {
+		return nil, fmt.Errorf("scenario probabilities must sum to more than zero")
+	}
+
+	analysis := &finance.ScenarioAnalysis{
+		Scenarios: make([]finance.ScenarioResult, 0, len(scenarios)),
+	}
+
+	for _, scenario := range scenarios {
+		valuation, err := CalculateDCF(companyData, scenario.Input)
+		if err != nil {
+			return nil, fmt.Errorf("scenario %q: %v", scenario.Name, err)
+		}
+
+		probability := scenario.Probability / totalWeight
+		analysis.Scenarios = append(analysis.Scenarios, finance.ScenarioResult{
+			Name:        scenario.Name,
+			Probability: probability,
+			Valuation:   valuation,
+		})
+		analysis.WeightedFairValue += probability * valuation.FairValuePerShare
+		analysis.CurrentPrice = valuation.CurrentPrice
+	}
+
+	if analysis.CurrentPrice > 0 {
+		analysis.WeightedUpsidePercent = ((analysis.WeightedFairValue - analysis.CurrentPrice) / analysis.CurrentPrice) * 100
+	}
+
+	return analysis, nil
+}
+
+// DefaultScenarios derives bear/base/bull scenarios around the base case inputs.
+// Bull and bear shift revenue growth and FCF margin by one standard deviation of
+// their historical annual values, falling back to fixed spreads without enough history.
+func DefaultScenarios(companyData *finance.CompanyData, base *DCFInput) ([]ScenarioInput, *finance.ScenarioDispersion, error) {
+	if base == nil {
+		base = &DCFInput{}
+	}
+
+	baseValuation, err := CalculateDCF(companyData, base)
+	if err != nil {
+		return nil, nil, err
+	}
+	assumptions := baseValuation.Assumptions
+
+	dispersion := historicalDispersion(companyData)
+
+	build := func(name string, probability float64, direction float64) ScenarioInput {
+		input := *base
+		input.RevenueGrowthRate = floatPtr(assumptions.RevenueGrowthRate + direction*dispersion.GrowthStdDev)
+		input.FCFMargin = floatPtr(math.Max(0, assumptions.FCFMargin+direction*dispersion.FCFMarginStdDev))
+		input.DiscountRate = floatPtr(assumptions.DiscountRate)
+		return ScenarioInput{Name: name, Probability: probability, Input: &input}
+	}
+
+	scenarios := []ScenarioInput{
+		build("bear", bullBearProbability, -1),
+		build("base", 1-2*bullBearProbability, 0),
+		build("bull", bullBearProbability, 1),
+	}
+
+	return scenarios, dispersion, nil
+}
+
+// historicalDispersion measures the standard deviation of annual revenue growth
+// and FCF margin over the last HistoryYears annual statements
+func historicalDispersion(companyData *finance.CompanyData) *finance.ScenarioDispersion {
+	dispersion := &finance.ScenarioDispersion{
+		Source:          "fixed_spread",
+		GrowthStdDev:    fallbackGrowthSpread,
+		FCFMarginStdDev: fallbackMarginSpread,
+	}
+
+	if companyData.HistoricalData == nil || len(companyData.HistoricalData.AnnualStatements) < minScenarioHistory {
+		return dispersion
+	}
+
+	annual := companyData.HistoricalData.AnnualStatements
+	if len(annual) > HistoryYears {
+		annual = annual[len(annual)-HistoryYears:]
+	}
+
+	var growthRates, fcfMargins []float64
+	for i, statement := range annual {
+		if statement.Revenue <= 0 {
+			continue
+		}
+		fcfMargins = append(fcfMargins, statement.FreeCashFlow/statement.Revenue)
+		// Growth is only measured across consecutive fiscal years; a gap would read as one year of growth
+		if i > 0 && annual[i-1].Revenue > 0 && statement.FiscalYear == annual[i-1].FiscalYear+1 {
+			growthRates = append(growthRates, statement.Revenue/annual[i-1].Revenue-1)
+		}
+	}
+
+	if len(growthRates) < minScenarioHistory-1 || len(fcfMargins) < minScenarioHistory {
+		return dispersion
+	}
+
+	dispersion.Source = "historical_dispersion"
+	dispersion.Years = len(fcfMargins)
+	dispersion.GrowthStdDev = stdDev(growthRates)
+	dispersion.FCFMarginStdDev = stdDev(fcfMargins)
+
+	// Very steady history would collapse bull and bear onto the base case
+	dispersion.GrowthStdDev = math.Max(dispersion.GrowthStdDev, minScenarioSpread)
+	dispersion.FCFMarginStdDev = math.Max(dispersion.FCFMarginStdDev, minScenarioSpread)
+
+	return dispersion
+}
+
+// stdDev returns the sample standard deviation
+func stdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	sumSquares := 0.0
+	for _, v := range values {
+		sumSquares += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sumSquares / float64(len(values)-1))
+}
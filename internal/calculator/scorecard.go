@@ -7,36 +7,64 @@ import (
 	"github.com/sshetty/finEdSkywalker/internal/finance"
 )
 
-// CalculateScorecard generates the Big 5 fundamental metrics scorecard
-func CalculateScorecard(companyData *finance.CompanyData) *finance.FundamentalScorecard {
+// CalculateScorecard generates the Big 5 fundamental metrics scorecard, rated
+// with the company's sector threshold profile, and scores it with a rule set.
+// A nil rule set uses the default. dcfInput supplies the WACC overrides for
+// the ROIC-WACC spread and may be nil.
+func CalculateScorecard(companyData *finance.CompanyData, ruleSet *RuleSet, dcfInput *DCFInput) *finance.FundamentalScorecard {
 	scorecard := &finance.FundamentalScorecard{}
+	profile := SelectThresholdProfile(companyData)
 
 	// 1. P/E Ratio
-	scorecard.PERatio = calculatePERatio(companyData)
+	scorecard.PERatio = calculatePERatio(companyData, profile)
 
 	// 2. Debt-to-Equity Ratio
-	scorecard.DebtToEquity = calculateDebtToEquity(companyData)
+	scorecard.DebtToEquity = calculateDebtToEquity(companyData, profile)
 
 	// 3. FCF Yield
-	scorecard.FCFYield = calculateFCFYield(companyData)
+	scorecard.FCFYield = calculateFCFYield(companyData, profile)
 
 	// 4. PEG Ratio
-	scorecard.PEGRatio = calculatePEGRatio(companyData)
+	scorecard.PEGRatio = calculatePEGRatio(companyData, profile)
+	scorecard.ForwardPE = calculateForwardPE(companyData, profile)
 
 	// 5. ROE (Return on Equity)
-	scorecard.ROE = calculateROE(companyData)
+	scorecard.ROE = calculateROE(companyData, profile)
 
-	// Calculate overall score
-	scorecard.OverallScore, scorecard.Summary = calculateOverallScore(scorecard)
+	// Quality checks across consecutive annual statements
+	if piotroski := CalculatePiotroski(companyData); piotroski != nil {
+		scorecard.Quality = &finance.QualitySection{Piotroski: piotroski}
+	}
+
+	// Return on invested capital, unaffected by buybacks shrinking equity
+	scorecard.CapitalEfficiency = CalculateCapitalEfficiency(companyData, dcfInput)
+
+	// Bankruptcy and earnings-manipulation screens
+	scorecard.RedFlags = CalculateRedFlags(companyData)
+
+	// Cross-check our EDGAR-derived ratios against the data vendor's
+	scorecard.Reconciliation = ReconcileMetrics(scorecard, companyData)
+
+	// Calculate overall and weighted scores from the rule set
+	if ruleSet == nil {
+		if defaultSet, err := LoadRuleSet(""); err == nil {
+			ruleSet = defaultSet
+		} else {
+			ruleSet = &builtinRuleSet
+		}
+	}
+	scorecard.RuleResults = applyRuleSet(scorecard, ruleSet)
+	scorecard.OverallScore, scorecard.Summary = calculateOverallScore(scorecard.RuleResults)
 
 	return scorecard
 }
 
 // calculatePERatio calculates and rates the P/E ratio
-func calculatePERatio(data *finance.CompanyData) finance.FundamentalMetric {
+func calculatePERatio(data *finance.CompanyData, profile ThresholdProfile) finance.FundamentalMetric {
 	metric := finance.FundamentalMetric{
 		Available: false,
 		Rating:    finance.RatingNA,
+		Profile:   profile.Name,
 	}
 
 	if data.Quote == nil || data.LatestFinancials == nil {
@@ -62,6 +90,7 @@ func calculatePERatio(data *finance.CompanyData) finance.FundamentalMetric {
 	if data.HistoricalData != nil && data.HistoricalData.PERatioAvg5Year > 0 {
 		fiveYearAvg := data.HistoricalData.PERatioAvg5Year
 		metric.FiveYearAvg = &fiveYearAvg
+		metric.Profile = "" // Rated against the company's own history, not the sector profile
 
 		// Rating logic
 		if peRatio < fiveYearAvg*0.9 {
@@ -75,11 +104,11 @@ func calculatePERatio(data *finance.CompanyData) finance.FundamentalMetric {
 			metric.Message = fmt.Sprintf("P/E (%.2f) is near 5-year average (%.2f)", peRatio, fiveYearAvg)
 		}
 	} else {
-		// No historical data, use general benchmarks
-		if peRatio < 15 {
+		// No historical data, use the sector profile's benchmarks
+		if peRatio < profile.PERatio.Green {
 			metric.Rating = finance.RatingGreen
 			metric.Message = fmt.Sprintf("P/E of %.2f suggests good value", peRatio)
-		} else if peRatio > 30 {
+		} else if peRatio > profile.PERatio.Yellow {
 			metric.Rating = finance.RatingRed
 			metric.Message = fmt.Sprintf("P/E of %.2f is relatively high", peRatio)
 		} else {
@@ -92,10 +121,11 @@ func calculatePERatio(data *finance.CompanyData) finance.FundamentalMetric {
 }
 
 // calculateDebtToEquity calculates and rates the Debt-to-Equity ratio
-func calculateDebtToEquity(data *finance.CompanyData) finance.FundamentalMetric {
+func calculateDebtToEquity(data *finance.CompanyData, profile ThresholdProfile) finance.FundamentalMetric {
 	metric := finance.FundamentalMetric{
 		Available: false,
 		Rating:    finance.RatingNA,
+		Profile:   profile.Name,
 	}
 
 	if data.LatestFinancials == nil {
@@ -113,14 +143,18 @@ func calculateDebtToEquity(data *finance.CompanyData) finance.FundamentalMetric
 	metric.Current = debtToEquity
 	metric.Available = true
 
+	if data.HistoricalData != nil {
+		metric.FiveYearAvg = averageNonZero(data.HistoricalData.DebtToEquityHistory)
+	}
+
 	// Rating logic (lower is better for long-term safety)
-	if debtToEquity < 0.5 {
+	if debtToEquity < profile.DebtToEquity.Green {
 		metric.Rating = finance.RatingGreen
 		metric.Message = fmt.Sprintf("Excellent debt levels (%.2f) - very safe", debtToEquity)
-	} else if debtToEquity < 1.0 {
+	} else if debtToEquity < profile.DebtToEquity.Yellow {
 		metric.Rating = finance.RatingYellow
 		metric.Message = fmt.Sprintf("Moderate debt levels (%.2f) - acceptable", debtToEquity)
-	} else if debtToEquity < 2.0 {
+	} else if profile.DebtToEquity.VeryHigh == 0 || debtToEquity < profile.DebtToEquity.VeryHigh {
 		metric.Rating = finance.RatingRed
 		metric.Message = fmt.Sprintf("High debt levels (%.2f) - risky", debtToEquity)
 	} else {
@@ -132,10 +166,11 @@ func calculateDebtToEquity(data *finance.CompanyData) finance.FundamentalMetric
 }
 
 // calculateFCFYield calculates and rates the Free Cash Flow Yield
-func calculateFCFYield(data *finance.CompanyData) finance.FundamentalMetric {
+func calculateFCFYield(data *finance.CompanyData, profile ThresholdProfile) finance.FundamentalMetric {
 	metric := finance.FundamentalMetric{
 		Available: false,
 		Rating:    finance.RatingNA,
+		Profile:   profile.Name,
 	}
 
 	if data.Quote == nil || data.LatestFinancials == nil {
@@ -154,11 +189,15 @@ func calculateFCFYield(data *finance.CompanyData) finance.FundamentalMetric {
 	metric.Current = fcfYield
 	metric.Available = true
 
+	if data.HistoricalData != nil {
+		metric.FiveYearAvg = averageNonZero(data.HistoricalData.FCFYieldHistory)
+	}
+
 	// Rating logic (higher is better)
-	if fcfYield > 8 {
+	if fcfYield > profile.FCFYield.Green {
 		metric.Rating = finance.RatingGreen
 		metric.Message = fmt.Sprintf("Excellent FCF yield (%.2f%%) - strong cash generation", fcfYield)
-	} else if fcfYield > 4 {
+	} else if fcfYield > profile.FCFYield.Yellow {
 		metric.Rating = finance.RatingYellow
 		metric.Message = fmt.Sprintf("Good FCF yield (%.2f%%)", fcfYield)
 	} else if fcfYield > 0 {
@@ -173,25 +212,33 @@ func calculateFCFYield(data *finance.CompanyData) finance.FundamentalMetric {
 }
 
 // calculatePEGRatio calculates and rates the PEG ratio
-func calculatePEGRatio(data *finance.CompanyData) finance.FundamentalMetric {
+func calculatePEGRatio(data *finance.CompanyData, profile ThresholdProfile) finance.FundamentalMetric {
 	metric := finance.FundamentalMetric{
 		Available: false,
 		Rating:    finance.RatingNA,
+		Profile:   profile.Name,
 	}
 
 	// PEG requires P/E ratio and growth rate
-	peMetric := calculatePERatio(data)
+	peMetric := calculatePERatio(data, profile)
 	if !peMetric.Available {
 		metric.Message = "P/E ratio not available"
 		return metric
 	}
 
-	// Use a default growth rate estimate (8%) if not provided
-	// In production, this would come from analyst estimates
-	growthRate := 8.0 // 8% default
+	// Growth from analyst consensus, else historical EPS CAGR
+	growth := EstimateEPSGrowth(data)
+	if growth == nil {
+		metric.Message = "No EPS growth available (no analyst estimates or positive EPS history)"
+		return metric
+	}
+	metric.GrowthRate = &growth.Rate
+	metric.GrowthSource = growth.Source
 
+	growthRate := growth.Rate * 100 // As percentage
 	if growthRate <= 0 {
-		metric.Message = "Growth rate not available or negative"
+		metric.Message = fmt.Sprintf("EPS growth of %.1f%% is not positive; PEG is not meaningful", growthRate)
+		metric.Rating = finance.RatingRed
 		return metric
 	}
 
@@ -201,11 +248,16 @@ func calculatePEGRatio(data *finance.CompanyData) finance.FundamentalMetric {
 	metric.Current = pegRatio
 	metric.Available = true
 
+	if peMetric.FiveYearAvg != nil {
+		avgPEG := *peMetric.FiveYearAvg / growthRate
+		metric.FiveYearAvg = &avgPEG
+	}
+
 	// Rating logic (lower is better, < 1 is undervalued)
-	if pegRatio < 1.0 {
+	if pegRatio < profile.PEGRatio.Green {
 		metric.Rating = finance.RatingGreen
 		metric.Message = fmt.Sprintf("PEG of %.2f suggests undervalued relative to growth", pegRatio)
-	} else if pegRatio < 1.5 {
+	} else if pegRatio < profile.PEGRatio.Yellow {
 		metric.Rating = finance.RatingYellow
 		metric.Message = fmt.Sprintf("PEG of %.2f is fairly valued", pegRatio)
 	} else {
@@ -213,16 +265,85 @@ func calculatePEGRatio(data *finance.CompanyData) finance.FundamentalMetric {
 		metric.Message = fmt.Sprintf("PEG of %.2f suggests overvalued relative to growth", pegRatio)
 	}
 
-	metric.Message += fmt.Sprintf(" (assuming %.0f%% growth)", growthRate)
+	metric.Message += fmt.Sprintf(" (%.1f%% EPS growth from %s)", growthRate, growthSourceLabel(growth.Source))
+
+	return metric
+}
+
+// calculateForwardPE rates price over next-year EPS: the analyst consensus when
+// available, else the latest annual EPS grown at the historical EPS CAGR
+func calculateForwardPE(data *finance.CompanyData, profile ThresholdProfile) finance.FundamentalMetric {
+	metric := finance.FundamentalMetric{
+		Available: false,
+		Rating:    finance.RatingNA,
+		Profile:   profile.Name,
+	}
+
+	if data.Quote == nil || data.Quote.CurrentPrice <= 0 {
+		metric.Message = "Current price not available"
+		return metric
+	}
+
+	trailingEPS, _ := latestAnnualEPS(data)
+	var forwardEPS float64
+	if estimate := nextYearEPSEstimate(data); estimate != nil && estimate.EPSAvg != 0 {
+		forwardEPS = estimate.EPSAvg
+		metric.GrowthSource = GrowthSourceAnalyst
+	} else if growth := historicalEPSGrowth(data); growth != nil {
+		forwardEPS = trailingEPS * (1 + growth.Rate)
+		metric.GrowthSource = GrowthSourceHistorical
+	} else {
+		metric.Message = "No EPS estimate or EPS history to project next year's earnings"
+		return metric
+	}
+
+	if trailingEPS > 0 {
+		growthRate := forwardEPS/trailingEPS - 1
+		metric.GrowthRate = &growthRate
+	}
+
+	if forwardEPS <= 0 {
+		metric.Message = "Next year's EPS is expected to be negative"
+		metric.Rating = finance.RatingRed
+		return metric
+	}
+
+	// Forward P/E = Price / next-year EPS
+	forwardPE := data.Quote.CurrentPrice / forwardEPS
+	metric.Current = forwardPE
+	metric.Available = true
+
+	// Rating logic (same sector cutoffs as trailing P/E)
+	if forwardPE < profile.PERatio.Green {
+		metric.Rating = finance.RatingGreen
+		metric.Message = fmt.Sprintf("Forward P/E of %.2f suggests good value", forwardPE)
+	} else if forwardPE > profile.PERatio.Yellow {
+		metric.Rating = finance.RatingRed
+		metric.Message = fmt.Sprintf("Forward P/E of %.2f is relatively high", forwardPE)
+	} else {
+		metric.Rating = finance.RatingYellow
+		metric.Message = fmt.Sprintf("Forward P/E of %.2f is moderate", forwardPE)
+	}
+
+	metric.Message += fmt.Sprintf(" (next-year EPS of %.2f from %s)", forwardEPS, growthSourceLabel(metric.GrowthSource))
 
 	return metric
 }
 
+// growthSourceLabel describes a growth source for metric messages
+func growthSourceLabel(source string) string {
+	if source == GrowthSourceAnalyst {
+		return "analyst estimates"
+	}
+	return "historical EPS CAGR"
+}
+
 // calculateROE calculates and rates the Return on Equity
-func calculateROE(data *finance.CompanyData) finance.FundamentalMetric {
+func calculateROE(data *finance.CompanyData, profile ThresholdProfile) finance.FundamentalMetric {
 	metric := finance.FundamentalMetric{
 		Available: false,
 		Rating:    finance.RatingNA,
+		Profile:   profile.Name,
 	}
 
 	if data.LatestFinancials == nil {
@@ -242,11 +363,15 @@ func calculateROE(data *finance.CompanyData) finance.FundamentalMetric {
 	metric.Current = roe
 	metric.Available = true
 
+	if data.HistoricalData != nil {
+		metric.FiveYearAvg = averageNonZero(data.HistoricalData.ROEHistory)
+	}
+
 	// Rating logic (higher is better - indicates management efficiency)
-	if roe > 20 {
+	if roe > profile.ROE.Green {
 		metric.Rating = finance.RatingGreen
 		metric.Message = fmt.Sprintf("Excellent ROE (%.2f%%) - highly efficient management", roe)
-	} else if roe > 15 {
+	} else if roe > profile.ROE.Yellow {
 		metric.Rating = finance.RatingYellow
 		metric.Message = fmt.Sprintf("Good ROE (%.2f%%) - solid management", roe)
 	} else if roe > 0 {
@@ -260,25 +385,17 @@ func calculateROE(data *finance.CompanyData) finance.FundamentalMetric {
 	return metric
 }
 
-// calculateOverallScore summarizes the scorecard
-func calculateOverallScore(scorecard *finance.FundamentalScorecard) (string, string) {
+// calculateOverallScore summarizes the rule set's metrics
+func calculateOverallScore(results []finance.RuleResult) (string, string) {
 	greenCount := 0
 	yellowCount := 0
 	redCount := 0
 	totalMetrics := 0
 
-	metrics := []finance.FundamentalMetric{
-		scorecard.PERatio,
-		scorecard.DebtToEquity,
-		scorecard.FCFYield,
-		scorecard.PEGRatio,
-		scorecard.ROE,
-	}
-
-	for _, metric := range metrics {
-		if metric.Available {
+	for _, result := range results {
+		if result.Available {
 			totalMetrics++
-			switch metric.Rating {
+			switch result.Rating {
 			case finance.RatingGreen:
 				greenCount++
 			case finance.RatingYellow:
@@ -290,7 +407,7 @@ func calculateOverallScore(scorecard *finance.FundamentalScorecard) (string, str
 	}
 
 	if totalMetrics == 0 {
-		return "0/5 metrics available", "Insufficient data for analysis"
+		return fmt.Sprintf("0/%d metrics available", len(results)), "Insufficient data for analysis"
 	}
 
 	healthyCount := greenCount + yellowCount
@@ -0,0 +1,182 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// Assumptions that can be varied on a sensitivity axis
+const (
+	SensitivityDiscountRate   = "discount_rate"
+	SensitivityTerminalGrowth = "terminal_growth"
+	SensitivityRevenueGrowth  = "revenue_growth"
+	SensitivityFCFMargin      = "fcf_margin"
+)
+
+// maxSensitivitySteps caps each axis so a grid stays at most 21 × 21 DCF runs
+const maxSensitivitySteps = 21
+
+// sensitivityDefaults holds the default half-width and step of each axis,
+// centred on the base case assumption
+var sensitivityDefaults = map[string]struct{ halfWidth, step float64 }{
+	SensitivityDiscountRate:   {0.02, 0.01},
+	SensitivityTerminalGrowth: {0.01, 0.005},
+	SensitivityRevenueGrowth:  {0.04, 0.02},
+	SensitivityFCFMargin:      {0.04, 0.02},
+}
+
+// SensitivityAxis describes one dimension of a sensitivity grid.
+// Nil bounds are derived from the base case assumption.
+type SensitivityAxis struct {
+	Assumption string
+	Min        *float64
+	Max        *float64
+	Step       *float64
+}
+
+// CalculateSensitivity runs CalculateDCF across a grid of two assumptions,
+// holding every other assumption at its base case value
+func CalculateSensitivity(companyData *finance.CompanyData, input *DCFInput, xAxis, yAxis SensitivityAxis) (*finance.SensitivityResult, error) {
+	if _, ok := sensitivityDefaults[xAxis.Assumption]; !ok {
+		return nil, fmt.Errorf("unknown sensitivity assumption %q", xAxis.Assumption)
+	}
+	if _, ok := sensitivityDefaults[yAxis.Assumption]; !ok {
+		return nil, fmt.Errorf("unknown sensitivity assumption %q", yAxis.Assumption)
+	}
+	if xAxis.Assumption == yAxis.Assumption {
+		return nil, fmt.Errorf("sensitivity axes must vary two different assumptions")
+	}
+
+	if input == nil {
+		input = &DCFInput{}
+	}
+
+	base, err := CalculateDCF(companyData, input)
+	if err != nil {
+		return nil, err
+	}
+
+	xValues, err := axisValues(xAxis, base.Assumptions)
+	if err != nil {
+		return nil, err
+	}
+	yValues, err := axisValues(yAxis, base.Assumptions)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &finance.SensitivityResult{
+		XAxis:         finance.SensitivityAxisValues{Assumption: xAxis.Assumption, Values: xValues},
+		YAxis:         finance.SensitivityAxisValues{Assumption: yAxis.Assumption, Values: yValues},
+		BaseFairValue: base.FairValuePerShare,
+		CurrentPrice:  base.CurrentPrice,
+		Assumptions:   base.Assumptions,
+		Cells:         make([][]finance.SensitivityCell, len(yValues)),
+	}
+
+	// Pin the base case so cells only differ in the two varied assumptions
+	pinned := *input
+	pinned.RevenueGrowthRate = floatPtr(base.Assumptions.RevenueGrowthRate)
+	pinned.ProfitMargin = floatPtr(base.Assumptions.ProfitMargin)
+	pinned.FCFMargin = floatPtr(base.Assumptions.FCFMargin)
+	pinned.DiscountRate = floatPtr(base.Assumptions.DiscountRate)
+	pinned.TerminalGrowthRate = floatPtr(base.Assumptions.TerminalGrowthRate)
+
+	for row, y := range yValues {
+		result.Cells[row] = make([]finance.SensitivityCell, len(xValues))
+		for col, x := range xValues {
+			cellInput := pinned
+			setSensitivityAssumption(&cellInput, xAxis.Assumption, x)
+			setSensitivityAssumption(&cellInput, yAxis.Assumption, y)
+
+			cell := finance.SensitivityCell{X: x, Y: y}
+
+			// The perpetuity formula is undefined when growth meets the discount rate
+			if *cellInput.DiscountRate > *cellInput.TerminalGrowthRate {
+				if valuation, err := CalculateDCF(companyData, &cellInput); err == nil {
+					cell.FairValuePerShare = valuation.FairValuePerShare
+					cell.UpsidePercent = valuation.UpsidePercent
+					cell.Valid = true
+				}
+			}
+
+			result.Cells[row][col] = cell
+		}
+	}
+
+	return result, nil
+}
+
+// axisValues expands an axis into its grid values
+func axisValues(axis SensitivityAxis, base finance.DCFAssumptions) ([]float64, error) {
+	defaults := sensitivityDefaults[axis.Assumption]
+	center := sensitivityBaseValue(axis.Assumption, base)
+
+	min := center - defaults.halfWidth
+	max := center + defaults.halfWidth
+	step := defaults.step
+	if axis.Min != nil {
+		min = *axis.Min
+	}
+	if axis.Max != nil {
+		max = *axis.Max
+	}
+	if axis.Step != nil {
+		step = *axis.Step
+	}
+
+	if step <= 0 {
+		return nil, fmt.Errorf("%s step must be positive", axis.Assumption)
+	}
+	if max < min {
+		return nil, fmt.Errorf("%s max must not be below min", axis.Assumption)
+	}
+
+	steps := int(math.Floor((max-min)/step+1e-9)) + 1
+	if steps > maxSensitivitySteps {
+		return nil, fmt.Errorf("%s range produces %d steps (max %d)", axis.Assumption, steps, maxSensitivitySteps)
+	}
+
+	values := make([]float64, steps)
+	for i := range values {
+		// Round away floating point drift (e.g., 0.30000000000000004)
+		values[i] = math.Round((min+float64(i)*step)*1e6) / 1e6
+	}
+	return values, nil
+}
+
+// sensitivityBaseValue returns the base case value of an assumption
+func sensitivityBaseValue(assumption string, base finance.DCFAssumptions) float64 {
+	switch assumption {
+	case SensitivityDiscountRate:
+		return base.DiscountRate
+	case SensitivityTerminalGrowth:
+		return base.TerminalGrowthRate
+	case SensitivityRevenueGrowth:
+		return base.RevenueGrowthRate
+	case SensitivityFCFMargin:
+		return base.FCFMargin
+	}
+	return 0
+}
+
+// setSensitivityAssumption overrides one assumption on a DCF input
+func setSensitivityAssumption(input *DCFInput, assumption string, value float64) {
+	switch assumption {
+	case SensitivityDiscountRate:
+		input.DiscountRate = floatPtr(value)
+	case SensitivityTerminalGrowth:
+		input.TerminalGrowthRate = floatPtr(value)
+	case SensitivityRevenueGrowth:
+		input.RevenueGrowthRate = floatPtr(value)
+	case SensitivityFCFMargin:
+		input.FCFMargin = floatPtr(value)
+	}
+}
+
+// floatPtr returns a pointer to a copy of v
+func floatPtr(v float64) *float64 {
+	return &v
+}
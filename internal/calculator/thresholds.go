@@ -0,0 +1,107 @@
+package calculator
+
+import (
+	_ "embed"
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+//go:embed threshold_profiles.json
+var thresholdProfilesJSON []byte
+
+// defaultProfileName is the profile applied when no sector profile matches
+const defaultProfileName = "default"
+
+var (
+	thresholdProfiles     []ThresholdProfile
+	thresholdProfilesOnce sync.Once
+)
+
+// Cutoffs rate a metric. For lower-is-better metrics a value below Green is GREEN
+// and below Yellow is YELLOW; for higher-is-better metrics a value above Green is
+// GREEN and above Yellow is YELLOW. Anything else is RED. VeryHigh optionally
+// splits RED for lower-is-better metrics: values at or above it are very high.
+type Cutoffs struct {
+	Green    float64 `json:"green"`
+	Yellow   float64 `json:"yellow"`
+	VeryHigh float64 `json:"very_high,omitempty"`
+}
+
+// ThresholdProfile holds scorecard cutoffs for a sector, matched by SIC code
+// range or Finnhub industry name
+type ThresholdProfile struct {
+	Name              string   `json:"name"`
+	Description       string   `json:"description"`
+	SICRanges         [][2]int `json:"sic_ranges,omitempty"`
+	FinnhubIndustries []string `json:"finnhub_industries,omitempty"`
+	PERatio           Cutoffs  `json:"pe_ratio"`
+	DebtToEquity      Cutoffs  `json:"debt_to_equity"`
+	FCFYield          Cutoffs  `json:"fcf_yield"`
+	PEGRatio          Cutoffs  `json:"peg_ratio"`
+	ROE               Cutoffs  `json:"roe"`
+}
+
+// builtinDefaultProfile is used if the embedded profile file fails to parse
+var builtinDefaultProfile = ThresholdProfile{
+	Name:         defaultProfileName,
+	Description:  "General-purpose cutoffs for companies without a sector profile",
+	PERatio:      Cutoffs{Green: 15, Yellow: 30},
+	DebtToEquity: Cutoffs{Green: 0.5, Yellow: 1.0, VeryHigh: 2.0},
+	FCFYield:     Cutoffs{Green: 8, Yellow: 4},
+	PEGRatio:     Cutoffs{Green: 1.0, Yellow: 1.5},
+	ROE:          Cutoffs{Green: 20, Yellow: 15},
+}
+
+// loadThresholdProfiles parses the embedded profile file once per Lambda container
+func loadThresholdProfiles() []ThresholdProfile {
+	thresholdProfilesOnce.Do(func() {
+		var file struct {
+			Profiles []ThresholdProfile `json:"profiles"`
+		}
+		if err := json.Unmarshal(thresholdProfilesJSON, &file); err != nil {
+			log.Printf("Failed to parse threshold profiles, using defaults: %v", err)
+			thresholdProfiles = []ThresholdProfile{builtinDefaultProfile}
+			return
+		}
+		thresholdProfiles = file.Profiles
+	})
+	return thresholdProfiles
+}
+
+// SelectThresholdProfile picks the scorecard profile for a company. The EDGAR SIC
+// code is checked first, then the Finnhub industry, then the default profile.
+func SelectThresholdProfile(data *finance.CompanyData) ThresholdProfile {
+	profiles := loadThresholdProfiles()
+
+	if code, err := strconv.Atoi(data.SIC); err == nil {
+		for _, profile := range profiles {
+			for _, r := range profile.SICRanges {
+				if code >= r[0] && code <= r[1] {
+					return profile
+				}
+			}
+		}
+	}
+
+	if data.Industry != "" {
+		for _, profile := range profiles {
+			for _, industry := range profile.FinnhubIndustries {
+				if strings.EqualFold(industry, data.Industry) {
+					return profile
+				}
+			}
+		}
+	}
+
+	for _, profile := range profiles {
+		if profile.Name == defaultProfileName {
+			return profile
+		}
+	}
+	return builtinDefaultProfile
+}
@@ -0,0 +1,89 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// Fallbacks when a WACC input can't be derived from the data
+const (
+	defaultBeta             = 1.0  // Market beta
+	statutoryTaxRate        = 0.21 // US federal corporate rate
+	defaultCreditSpread     = 0.02 // Added to the risk-free rate for cost of debt
+	maxReasonableCostOfDebt = 0.20 // Anything higher usually means mismatched debt/interest data
+)
+
+// CalculateWACC computes the weighted average cost of capital.
+// Cost of equity comes from CAPM; cost of debt is interest expense over total debt.
+func CalculateWACC(data *finance.CompanyData, riskFreeRate, equityRiskPremium float64) *finance.WACCBreakdown {
+	wacc := &finance.WACCBreakdown{
+		RiskFreeRate:      riskFreeRate,
+		EquityRiskPremium: equityRiskPremium,
+	}
+
+	// Cost of Equity = Risk-Free Rate + Beta × Equity Risk Premium
+	wacc.Beta = data.Beta
+	if wacc.Beta <= 0 {
+		wacc.Beta = defaultBeta
+		wacc.Notes = append(wacc.Notes, "Beta unavailable; assuming market beta of 1.0")
+	}
+	wacc.CostOfEquity = riskFreeRate + wacc.Beta*equityRiskPremium
+
+	financials := data.LatestFinancials
+	if financials != nil {
+		wacc.DebtValue = financials.TotalDebt
+	}
+
+	// Cost of Debt = Interest Expense / Total Debt
+	if financials != nil && financials.TotalDebt > 0 && financials.InterestExpense > 0 {
+		wacc.PreTaxCostOfDebt = financials.InterestExpense / financials.TotalDebt
+	}
+	if wacc.PreTaxCostOfDebt > maxReasonableCostOfDebt {
+		wacc.Notes = append(wacc.Notes, fmt.Sprintf("Implied cost of debt %.1f%% exceeds the %.0f%% cap; cost of debt set to risk-free rate + 2%%",
+			wacc.PreTaxCostOfDebt*100, maxReasonableCostOfDebt*100))
+		wacc.PreTaxCostOfDebt = riskFreeRate + defaultCreditSpread
+	} else if wacc.PreTaxCostOfDebt <= 0 {
+		wacc.PreTaxCostOfDebt = riskFreeRate + defaultCreditSpread
+		if wacc.DebtValue > 0 {
+			wacc.Notes = append(wacc.Notes, "Interest expense unavailable; cost of debt set to risk-free rate + 2%")
+		}
+	}
+
+	// Effective Tax Rate = Income Tax Expense / Pre-Tax Income
+	wacc.TaxRate = statutoryTaxRate
+	if financials != nil && financials.PretaxIncome > 0 {
+		effective := financials.IncomeTaxExpense / financials.PretaxIncome
+		if effective > 0 && effective < 0.5 {
+			wacc.TaxRate = effective
+		} else {
+			wacc.Notes = append(wacc.Notes, "Effective tax rate out of range; using 21% statutory rate")
+		}
+	} else {
+		wacc.Notes = append(wacc.Notes, "Pre-tax income unavailable; using 21% statutory tax rate")
+	}
+	wacc.AfterTaxCostOfDebt = wacc.PreTaxCostOfDebt * (1 - wacc.TaxRate)
+
+	// Capital weights: market value of equity, book value of debt
+	if data.Quote != nil {
+		wacc.EquityValue = data.Quote.MarketCap
+		if wacc.EquityValue <= 0 && data.SharesOutstanding > 0 {
+			wacc.EquityValue = data.Quote.CurrentPrice * data.SharesOutstanding * 1_000_000 // Shares in millions
+		}
+	}
+
+	totalCapital := wacc.EquityValue + wacc.DebtValue
+	if wacc.EquityValue <= 0 || totalCapital <= 0 {
+		// Without a market cap, weight everything to equity
+		wacc.EquityWeight = 1
+		wacc.DebtWeight = 0
+		wacc.Notes = append(wacc.Notes, "Market cap unavailable; WACC equals cost of equity")
+	} else {
+		wacc.EquityWeight = wacc.EquityValue / totalCapital
+		wacc.DebtWeight = wacc.DebtValue / totalCapital
+	}
+
+	wacc.WACC = wacc.EquityWeight*wacc.CostOfEquity + wacc.DebtWeight*wacc.AfterTaxCostOfDebt
+
+	return wacc
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -18,8 +19,18 @@ type Config struct {
 
 	// API Settings
 	RequestTimeout int // seconds
+
+	// Valuation Settings (CAPM inputs for the default discount rate)
+	RiskFreeRate      float64 // e.g., 0.043 for a 4.3% 10-year Treasury yield
+	EquityRiskPremium float64 // e.g., 0.055 for 5.5%
 }
 
+// Default CAPM inputs when not configured
+const (
+	defaultRiskFreeRate      = 0.043
+	defaultEquityRiskPremium = 0.055
+)
+
 // Global config instance
 var AppConfig *Config
 
@@ -31,6 +42,9 @@ func Load() (*Config, error) {
 		JWTSecret:      os.Getenv("JWT_SECRET"),
 		UseMockData:    strings.ToLower(os.Getenv("USE_MOCK_DATA")) == "true",
 		RequestTimeout: 10, // default 10 seconds
+
+		RiskFreeRate:      getEnvFloat("RISK_FREE_RATE", defaultRiskFreeRate),
+		EquityRiskPremium: getEnvFloat("EQUITY_RISK_PREMIUM", defaultEquityRiskPremium),
 	}
 
 	// Validate required configuration
@@ -67,9 +81,11 @@ func GetConfig() *Config {
 		if err != nil {
 			// Return a config with defaults for graceful degradation
 			return &Config{
-				UseMockData:    true,
-				RequestTimeout: 10,
-				EDGARUserAgent: "finEdSkywalker/1.0",
+				UseMockData:       true,
+				RequestTimeout:    10,
+				EDGARUserAgent:    "finEdSkywalker/1.0",
+				RiskFreeRate:      getEnvFloat("RISK_FREE_RATE", defaultRiskFreeRate),
+				EquityRiskPremium: getEnvFloat("EQUITY_RISK_PREMIUM", defaultEquityRiskPremium),
 			}
 		}
 		return config
@@ -94,3 +110,16 @@ func (c *Config) Validate() error {
 func (c *Config) IsMockMode() bool {
 	return c.UseMockData
 }
+
+// getEnvFloat reads a float environment variable, returning defaultVal if unset or invalid
+func getEnvFloat(name string, defaultVal float64) float64 {
+	val := os.Getenv(name)
+	if val == "" {
+		return defaultVal
+	}
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return defaultVal
+	}
+	return f
+}
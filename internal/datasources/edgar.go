@@ -24,9 +24,10 @@ type EDGARClient struct {
 	userAgent         string
 	httpClient        *http.Client
 	useMock           bool
-	cikCache          map[string]string // ticker -> CIK mapping
-	tickerMapCache    map[string]string // Full SEC ticker->CIK map (lazy loaded)
-	tickerMapLoadedAt time.Time         // Track when ticker map was last loaded
+	cikCache          map[string]string             // ticker -> CIK mapping
+	tickerMapCache    map[string]string             // Full SEC ticker->CIK map (lazy loaded)
+	tickerMapLoadedAt time.Time                     // Track when ticker map was last loaded
+	factsCache        map[string]*edgarCompanyFacts // CIK -> raw company facts
 }
 
 // EDGAR Company Facts response structure
@@ -77,6 +78,7 @@ func NewEDGARClient() *EDGARClient {
 		},
 		useMock:        cfg.IsMockMode(),
 		cikCache:       make(map[string]string),
+		factsCache:     make(map[string]*edgarCompanyFacts),
 		tickerMapCache: nil, // Lazy loaded on first miss
 	}
 }
@@ -87,12 +89,29 @@ func (c *EDGARClient) GetCompanyFacts(ticker string) (*finance.FinancialStatemen
 		return c.getMockFinancials(ticker), nil
 	}
 
+	facts, err := c.fetchCompanyFacts(ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the facts into our FinancialStatement structure
+	statement := c.parseFinancialStatement(facts)
+	return statement, nil
+}
+
+// fetchCompanyFacts downloads the raw companyfacts payload for a ticker.
+// Payloads are cached per client so the latest and historical views share one download.
+func (c *EDGARClient) fetchCompanyFacts(ticker string) (*edgarCompanyFacts, error) {
 	// First, get the CIK for the ticker
 	cik, err := c.getCIK(ticker)
 	if err != nil {
 		return nil, err
 	}
 
+	if facts, ok := c.factsCache[cik]; ok {
+		return facts, nil
+	}
+
 	// Fetch company facts
 	endpoint := fmt.Sprintf("%s/api/xbrl/companyfacts/CIK%s.json", edgarBaseURL, cik)
 
@@ -134,9 +153,8 @@ func (c *EDGARClient) GetCompanyFacts(ticker string) (*finance.FinancialStatemen
 		}
 	}
 
-	// Parse the facts into our FinancialStatement structure
-	statement := c.parseFinancialStatement(&facts)
-	return statement, nil
+	c.factsCache[cik] = &facts
+	return &facts, nil
 }
 
 // ensureTickerMapFresh checks if ticker map cache needs refresh and reloads if stale
@@ -295,127 +313,50 @@ func (c *EDGARClient) loadTickerMap() (map[string]string, error) {
 	return result, nil
 }
 
-// parseFinancialStatement extracts relevant financial data from EDGAR facts
+// parseFinancialStatement extracts the latest trailing-twelve-month statement from EDGAR facts
 func (c *EDGARClient) parseFinancialStatement(facts *edgarCompanyFacts) *finance.FinancialStatement {
-	statement := &finance.FinancialStatement{}
-
-	// Get the most recent annual filing data
 	// EDGAR uses US-GAAP taxonomy
 	usGAAP, ok := facts.Facts["us-gaap"]
 	if !ok {
-		return statement
-	}
-
-	// Extract key metrics (take most recent annual data)
-	statement.Revenue = c.getLatestValue(usGAAP, "Revenues")
-	if statement.Revenue == 0 {
-		statement.Revenue = c.getLatestValue(usGAAP, "RevenueFromContractWithCustomerExcludingAssessedTax")
-	}
-
-	statement.NetIncome = c.getLatestValue(usGAAP, "NetIncomeLoss")
-	statement.TotalAssets = c.getLatestValue(usGAAP, "Assets")
-	statement.TotalLiabilities = c.getLatestValue(usGAAP, "Liabilities")
-	statement.ShareholdersEquity = c.getLatestValue(usGAAP, "StockholdersEquity")
-
-	// Debt metrics
-	longTermDebt := c.getLatestValue(usGAAP, "LongTermDebt")
-	shortTermDebt := c.getLatestValue(usGAAP, "ShortTermBorrowings")
-	statement.TotalDebt = longTermDebt + shortTermDebt
-	if statement.TotalDebt == 0 {
-		statement.TotalDebt = c.getLatestValue(usGAAP, "DebtCurrent")
-	}
-
-	// Cash flow metrics
-	statement.OperatingCashFlow = c.getLatestValue(usGAAP, "NetCashProvidedByUsedInOperatingActivities")
-	statement.CapEx = c.getLatestValue(usGAAP, "PaymentsToAcquirePropertyPlantAndEquipment")
-
-	// Calculate Free Cash Flow
-	if statement.OperatingCashFlow > 0 && statement.CapEx > 0 {
-		statement.FreeCashFlow = statement.OperatingCashFlow - statement.CapEx
-	}
-
-	// Set metadata from the most recent filing
-	if len(usGAAP) > 0 {
-		for _, fact := range usGAAP {
-			if len(fact.Units) > 0 {
-				for _, values := range fact.Units {
-					if len(values) > 0 {
-						latest := values[len(values)-1]
-						statement.FiscalYear = latest.FY
-						statement.Period = fmt.Sprintf("%d-%s", latest.FY, latest.FP)
-						if latest.Filed != "" {
-							if filedDate, err := time.Parse("2006-01-02", latest.Filed); err == nil {
-								statement.FilingDate = filedDate
-							}
-						}
-						if latest.End != "" {
-							if endDate, err := time.Parse("2006-01-02", latest.End); err == nil {
-								statement.ReportDate = endDate
-							}
-						}
-						break
-					}
-				}
-				break
-			}
-		}
-	}
-
-	return statement
-}
-
-// getLatestValue extracts the most recent value for a given fact name
-func (c *EDGARClient) getLatestValue(facts map[string]edgarFact, factName string) float64 {
-	fact, ok := facts[factName]
-	if !ok {
-		return 0
-	}
-
-	// Look for USD values
-	var latestValue float64
-	var latestDate string
-
-	for unit, values := range fact.Units {
-		// Prefer USD values
-		if unit != "USD" && unit != "USD/shares" {
-			continue
-		}
-
-		for _, value := range values {
-			// Prefer annual filings (10-K) over quarterly (10-Q)
-			if value.Form != "10-K" && value.Form != "10-Q" {
-				continue
-			}
-
-			// Get the most recent value
-			if value.End > latestDate {
-				latestDate = value.End
-				// Convert json.Number to float64
-				if val, err := value.Val.Float64(); err == nil {
-					latestValue = val
-				}
-			}
-		}
+		return &finance.FinancialStatement{}
 	}
 
-	return latestValue
+	return buildTTMStatement(usGAAP)
 }
 
 // Mock data for testing
 func (c *EDGARClient) getMockFinancials(ticker string) *finance.FinancialStatement {
 	return &finance.FinancialStatement{
-		Revenue:            394328000000, // $394B
-		NetIncome:          96995000000,  // $97B
-		TotalAssets:        352755000000, // $353B
-		TotalLiabilities:   290437000000, // $290B
-		TotalDebt:          109280000000, // $109B
-		ShareholdersEquity: 62318000000,  // $62B
-		OperatingCashFlow:  110543000000, // $110B
-		CapEx:              10959000000,  // $11B
-		FreeCashFlow:       99584000000,  // $99.5B
-		Period:             "2024-FY",
-		FiscalYear:         2024,
-		ReportDate:         time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC),
-		FilingDate:         time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+		Revenue:                  394328000000, // $394B
+		NetIncome:                96995000000,  // $97B
+		EPS:                      6.06,
+		DividendsPerShare:        0.96,
+		GrossProfit:              180683000000, // $181B
+		OperatingIncome:          123216000000, // $123B
+		DepreciationAmortization: 11445000000,  // $11.4B
+		SGAExpense:               26097000000,  // $26.1B
+		InterestExpense:          3933000000,   // $3.9B
+		IncomeTaxExpense:         16741000000,  // $16.7B
+		PretaxIncome:             113736000000, // $114B
+		TotalAssets:              352755000000, // $353B
+		CurrentAssets:            152987000000, // $153B
+		AccountsReceivable:       33410000000,  // $33.4B
+		PropertyPlantEquipment:   45680000000,  // $45.7B
+		TotalLiabilities:         290437000000, // $290B
+		CurrentLiabilities:       176392000000, // $176B
+		TotalDebt:                109280000000, // $109B
+		LongTermDebt:             85750000000,  // $85.8B
+		ShareholdersEquity:       62318000000,  // $62B
+		RetainedEarnings:         -19154000000, // Accumulated deficit from buybacks
+		Cash:                     29965000000,  // $30B
+		ShortTermInvestments:     31590000000,  // $31.6B
+		OperatingCashFlow:        110543000000, // $110B
+		CapEx:                    10959000000,  // $11B
+		FreeCashFlow:             99584000000,  // $99.5B
+		Period:                   "2024-FY",
+		PeriodBasis:              finance.BasisFY,
+		FiscalYear:               2024,
+		ReportDate:               time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC),
+		FilingDate:               time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
 	}
 }
@@ -0,0 +1,759 @@
+package datasources
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// Period duration windows (in days) used to tell annual values from quarterly ones.
+// EDGAR tags a 10-K's comparative years with the filing's FY, so duration is the
+// only reliable way to classify a value.
+const (
+	annualMinDays  = 340
+	annualMaxDays  = 390
+	quarterMinDays = 80
+	quarterMaxDays = 100
+	ytdMaxDays     = 290 // Nine-month year-to-date periods
+)
+
+// periodSpan selects which reporting period a series is built for
+type periodSpan int
+
+const (
+	spanAnnual periodSpan = iota
+	spanQuarterly
+)
+
+// edgarConcept maps a FinancialStatement field to its US-GAAP tags (in priority order)
+type edgarConcept struct {
+	field   string // JSON name of the FinancialStatement field
+	tags    []string
+	sums    [][]string // Tag combinations summed per period (in priority order; "-" subtracts), used instead of tags
+	unit    string
+	instant bool // Balance sheet values are point-in-time and have no start date
+	anchor  bool // Periods reporting this concept define the statement periods
+	assign  func(s *finance.FinancialStatement, v float64)
+}
+
+// statementConcepts lists every concept extracted into a FinancialStatement
+var statementConcepts = []edgarConcept{
+	// Income Statement
+	{
+		field:  "revenue",
+		tags:   []string{"Revenues", "RevenueFromContractWithCustomerExcludingAssessedTax", "SalesRevenueNet"},
+		unit:   "USD",
+		anchor: true,
+		assign: func(s *finance.FinancialStatement, v float64) { s.Revenue = v },
+	},
+	{
+		field:  "net_income",
+		tags:   []string{"NetIncomeLoss"},
+		unit:   "USD",
+		anchor: true,
+		assign: func(s *finance.FinancialStatement, v float64) { s.NetIncome = v },
+	},
+	{
+		field:  "eps",
+		tags:   []string{"EarningsPerShareDiluted", "EarningsPerShareBasic"},
+		unit:   "USD/shares",
+		assign: func(s *finance.FinancialStatement, v float64) { s.EPS = v },
+	},
+	{
+		field:  "dividends_per_share",
+		tags:   []string{"CommonStockDividendsPerShareDeclared", "CommonStockDividendsPerShareCashPaid"},
+		unit:   "USD/shares",
+		assign: func(s *finance.FinancialStatement, v float64) { s.DividendsPerShare = v },
+	},
+	{
+		field:  "gross_profit",
+		tags:   []string{"GrossProfit"},
+		unit:   "USD",
+		assign: func(s *finance.FinancialStatement, v float64) { s.GrossProfit = v },
+	},
+	{
+		field:  "operating_income",
+		tags:   []string{"OperatingIncomeLoss"},
+		unit:   "USD",
+		assign: func(s *finance.FinancialStatement, v float64) { s.OperatingIncome = v },
+	},
+	{
+		field:  "depreciation_amortization",
+		tags:   []string{"DepreciationDepletionAndAmortization", "DepreciationAndAmortization", "DepreciationAmortizationAndAccretionNet"},
+		unit:   "USD",
+		assign: func(s *finance.FinancialStatement, v float64) { s.DepreciationAmortization = v },
+	},
+	{
+		field:  "sga_expense",
+		tags:   []string{"SellingGeneralAndAdministrativeExpense"},
+		unit:   "USD",
+		assign: func(s *finance.FinancialStatement, v float64) { s.SGAExpense = v },
+	},
+	{
+		field:  "interest_expense",
+		tags:   []string{"InterestExpense", "InterestExpenseNonoperating", "InterestExpenseDebt"},
+		unit:   "USD",
+		assign: func(s *finance.FinancialStatement, v float64) { s.InterestExpense = v },
+	},
+	{
+		field:  "income_tax_expense",
+		tags:   []string{"IncomeTaxExpenseBenefit"},
+		unit:   "USD",
+		assign: func(s *finance.FinancialStatement, v float64) { s.IncomeTaxExpense = v },
+	},
+	{
+		field: "pretax_income",
+		tags: []string{
+			"IncomeLossFromContinuingOperationsBeforeIncomeTaxesExtraordinaryItemsNoncontrollingInterest",
+			"IncomeLossFromContinuingOperationsBeforeIncomeTaxesMinorityInterestAndIncomeLossFromEquityMethodInvestments",
+		},
+		unit:   "USD",
+		assign: func(s *finance.FinancialStatement, v float64) { s.PretaxIncome = v },
+	},
+
+	// Balance Sheet
+	{
+		field:   "total_assets",
+		tags:    []string{"Assets"},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.TotalAssets = v },
+	},
+	{
+		field:   "current_assets",
+		tags:    []string{"AssetsCurrent"},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.CurrentAssets = v },
+	},
+	{
+		field:   "accounts_receivable",
+		tags:    []string{"AccountsReceivableNetCurrent", "ReceivablesNetCurrent"},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.AccountsReceivable = v },
+	},
+	{
+		field:   "property_plant_equipment",
+		tags:    []string{"PropertyPlantAndEquipmentNet"},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.PropertyPlantEquipment = v },
+	},
+	{
+		field:   "total_liabilities",
+		tags:    []string{"Liabilities"},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.TotalLiabilities = v },
+	},
+	{
+		field:   "current_liabilities",
+		tags:    []string{"LiabilitiesCurrent"},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.CurrentLiabilities = v },
+	},
+	{
+		field:   "shareholders_equity",
+		tags:    []string{"StockholdersEquity"},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.ShareholdersEquity = v },
+	},
+	{
+		field:   "retained_earnings",
+		tags:    []string{"RetainedEarningsAccumulatedDeficit"},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.RetainedEarnings = v },
+	},
+	{
+		// LongTermDebt and DebtCurrent both include current maturities, so they are
+		// never combined: each noncurrent/total tag is only paired with the matching
+		// current tag. Current debt alone is used when nothing else is reported.
+		field: "total_debt",
+		sums: [][]string{
+			{"LongTermDebtNoncurrent", "DebtCurrent"},
+			{"LongTermDebt", "ShortTermBorrowings"},
+			{"DebtCurrent"},
+			{"ShortTermBorrowings"},
+		},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.TotalDebt = v },
+	},
+	{
+		field: "long_term_debt",
+		sums: [][]string{
+			{"LongTermDebtNoncurrent"},
+			{"LongTermDebt", "-LongTermDebtCurrent"},
+		},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.LongTermDebt = v },
+	},
+	{
+		field:   "cash",
+		tags:    []string{"CashAndCashEquivalentsAtCarryingValue", "CashCashEquivalentsRestrictedCashAndRestrictedCashEquivalents"},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.Cash = v },
+	},
+	{
+		field:   "short_term_investments",
+		tags:    []string{"MarketableSecuritiesCurrent", "ShortTermInvestments", "AvailableForSaleSecuritiesDebtSecuritiesCurrent"},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.ShortTermInvestments = v },
+	},
+	{
+		field:   "minority_interest",
+		tags:    []string{"MinorityInterest"},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.MinorityInterest = v },
+	},
+	{
+		field:   "preferred_equity",
+		tags:    []string{"PreferredStockValue", "PreferredStockValueOutstanding"},
+		unit:    "USD",
+		instant: true,
+		assign:  func(s *finance.FinancialStatement, v float64) { s.PreferredEquity = v },
+	},
+
+	// Cash Flow
+	{
+		field:  "operating_cash_flow",
+		tags:   []string{"NetCashProvidedByUsedInOperatingActivities"},
+		unit:   "USD",
+		assign: func(s *finance.FinancialStatement, v float64) { s.OperatingCashFlow = v },
+	},
+	{
+		field:  "capex",
+		tags:   []string{"PaymentsToAcquirePropertyPlantAndEquipment"},
+		unit:   "USD",
+		assign: func(s *finance.FinancialStatement, v float64) { s.CapEx = v },
+	},
+}
+
+// periodValue is a single reported value for one concept over one period
+type periodValue struct {
+	start time.Time
+	end   time.Time
+	value float64
+	fp    string
+	filed time.Time
+}
+
+// GetFinancialHistory fetches annual and quarterly statements for a company.
+// Both series are ordered oldest first.
+func (c *EDGARClient) GetFinancialHistory(ticker string) (*finance.HistoricalMetrics, error) {
+	if c.useMock {
+		return c.getMockHistory(ticker), nil
+	}
+
+	facts, err := c.fetchCompanyFacts(ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &finance.HistoricalMetrics{}
+
+	usGAAP, ok := facts.Facts["us-gaap"]
+	if !ok {
+		return history, nil
+	}
+
+	history.AnnualStatements = buildStatements(usGAAP, spanAnnual)
+	history.QuarterlyStatements = buildStatements(usGAAP, spanQuarterly)
+
+	return history, nil
+}
+
+// conceptSeries collects every statement concept's values for a period type.
+// Quarterly flow series include Q4 values derived from the annual totals.
+func conceptSeries(usGAAP map[string]edgarFact, span periodSpan, annualSeries []map[string]periodValue) []map[string]periodValue {
+	series := make([]map[string]periodValue, len(statementConcepts))
+	for i, concept := range statementConcepts {
+		series[i] = collectPeriodValues(usGAAP, concept, span)
+		if span == spanQuarterly && !concept.instant {
+			deriveQuartersFromYTD(series[i], collectYTDValues(usGAAP, concept))
+			deriveFourthQuarters(series[i], annualSeries[i])
+		}
+	}
+	return series
+}
+
+// buildStatements assembles one FinancialStatement per reporting period
+func buildStatements(usGAAP map[string]edgarFact, span periodSpan) []finance.FinancialStatement {
+	// Annual periods are needed to derive Q4 values and quarter fiscal years
+	annualSeries := conceptSeries(usGAAP, spanAnnual, nil)
+
+	series := annualSeries
+	if span == spanQuarterly {
+		series = conceptSeries(usGAAP, spanQuarterly, annualSeries)
+	}
+
+	// Statement periods are defined by the anchor concepts (revenue, net income)
+	periods := make(map[string]periodValue)
+	for i, concept := range statementConcepts {
+		if !concept.anchor {
+			continue
+		}
+		for end, pv := range series[i] {
+			if _, exists := periods[end]; !exists {
+				periods[end] = pv
+			}
+		}
+	}
+
+	fiscalYearEnds := annualPeriodEnds(annualSeries)
+
+	statements := make([]finance.FinancialStatement, 0, len(periods))
+	for end, period := range periods {
+		statement := finance.FinancialStatement{
+			ReportDate: period.end,
+		}
+
+		for i, concept := range statementConcepts {
+			pv, ok := series[i][end]
+			if !ok {
+				continue
+			}
+			concept.assign(&statement, pv.value)
+			if pv.filed.After(statement.FilingDate) {
+				statement.FilingDate = pv.filed
+			}
+		}
+
+		deriveFreeCashFlow(&statement)
+
+		if span == spanAnnual {
+			statement.FiscalYear = period.end.Year()
+			statement.Period = fmt.Sprintf("%d-FY", statement.FiscalYear)
+			statement.PeriodBasis = finance.BasisFY
+		} else {
+			statement.FiscalYear = fiscalYearForQuarter(period.end, fiscalYearEnds)
+			statement.Period = fmt.Sprintf("%d-%s", statement.FiscalYear, quarterLabel(period.fp))
+			statement.PeriodBasis = finance.BasisQuarter
+		}
+
+		statements = append(statements, statement)
+	}
+
+	sort.Slice(statements, func(i, j int) bool {
+		return statements[i].ReportDate.Before(statements[j].ReportDate)
+	})
+
+	return statements
+}
+
+// collectPeriodValues returns a concept's values keyed by period end date.
+// Tags are merged in priority order (companies switch tags over the years) and
+// later filings replace earlier ones so restatements win.
+func collectPeriodValues(usGAAP map[string]edgarFact, concept edgarConcept, span periodSpan) map[string]periodValue {
+	result := make(map[string]periodValue)
+
+	sources := make([]map[string]periodValue, 0, len(concept.tags)+len(concept.sums))
+	for _, tag := range concept.tags {
+		sources = append(sources, collectTagValues(usGAAP, tag, concept, span))
+	}
+	for _, tags := range concept.sums {
+		sources = append(sources, sumTagValues(usGAAP, tags, concept, span))
+	}
+
+	// Higher priority tags win; lower priority tags only fill gaps
+	for _, tagValues := range sources {
+		for end, pv := range tagValues {
+			if _, exists := result[end]; !exists {
+				result[end] = pv
+			}
+		}
+	}
+
+	return result
+}
+
+// sumTagValues adds up a tag combination for every period where its first tag is
+// reported; the remaining tags count as zero when a period omits them. A tag
+// prefixed with "-" is subtracted.
+func sumTagValues(usGAAP map[string]edgarFact, tags []string, concept edgarConcept, span periodSpan) map[string]periodValue {
+	result := collectTagValues(usGAAP, tags[0], concept, span)
+	for _, tag := range tags[1:] {
+		sign := 1.0
+		if strings.HasPrefix(tag, "-") {
+			sign = -1
+			tag = tag[1:]
+		}
+		for end, pv := range collectTagValues(usGAAP, tag, concept, span) {
+			total, ok := result[end]
+			if !ok {
+				continue
+			}
+			total.value += sign * pv.value
+			if pv.filed.After(total.filed) {
+				total.filed = pv.filed
+			}
+			result[end] = total
+		}
+	}
+	return result
+}
+
+// collectTagValues returns one tag's values keyed by period end date, keeping the
+// latest filing for each period
+func collectTagValues(usGAAP map[string]edgarFact, tag string, concept edgarConcept, span periodSpan) map[string]periodValue {
+	tagValues := make(map[string]periodValue)
+
+	fact, ok := usGAAP[tag]
+	if !ok {
+		return tagValues
+	}
+
+	for _, value := range fact.Units[concept.unit] {
+		if !matchesSpan(value, concept.instant, span) {
+			continue
+		}
+
+		val, err := value.Val.Float64()
+		if err != nil {
+			continue
+		}
+
+		end, err := time.Parse("2006-01-02", value.End)
+		if err != nil {
+			continue
+		}
+
+		pv := periodValue{
+			end:   end,
+			value: val,
+			fp:    value.FP,
+		}
+		if value.Start != "" {
+			if start, err := time.Parse("2006-01-02", value.Start); err == nil {
+				pv.start = start
+			}
+		}
+		if filed, err := time.Parse("2006-01-02", value.Filed); err == nil {
+			pv.filed = filed
+		}
+
+		if existing, ok := tagValues[value.End]; ok && existing.filed.After(pv.filed) {
+			continue
+		}
+		tagValues[value.End] = pv
+	}
+
+	return tagValues
+}
+
+// matchesSpan reports whether a raw EDGAR value belongs to the requested period type
+func matchesSpan(value edgarFactValue, instant bool, span periodSpan) bool {
+	switch value.Form {
+	case "10-K", "10-K/A", "10-Q", "10-Q/A":
+	default:
+		return false
+	}
+
+	isAnnualForm := value.Form == "10-K" || value.Form == "10-K/A"
+
+	if instant {
+		if value.Start != "" {
+			return false
+		}
+		if span == spanAnnual {
+			return isAnnualForm && value.FP == "FY"
+		}
+		return true
+	}
+
+	days := periodDays(value.Start, value.End)
+	if span == spanAnnual {
+		return isAnnualForm && value.FP == "FY" && days >= annualMinDays && days <= annualMaxDays
+	}
+	return days >= quarterMinDays && days <= quarterMaxDays
+}
+
+// periodDays returns the length of a reporting period in days (0 if unparseable)
+func periodDays(start, end string) int {
+	startDate, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		return 0
+	}
+	endDate, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		return 0
+	}
+	return int(endDate.Sub(startDate).Hours() / 24)
+}
+
+// collectYTDValues returns a flow concept's cumulative 10-Q values (three, six and
+// nine months from the fiscal year start). Cash flow statements are only filed this way.
+func collectYTDValues(usGAAP map[string]edgarFact, concept edgarConcept) []periodValue {
+	var values []periodValue
+	for _, tag := range concept.tags {
+		fact, ok := usGAAP[tag]
+		if !ok {
+			continue
+		}
+		for _, value := range fact.Units[concept.unit] {
+			if value.Form != "10-Q" && value.Form != "10-Q/A" {
+				continue
+			}
+			days := periodDays(value.Start, value.End)
+			if days < quarterMinDays || days > ytdMaxDays {
+				continue
+			}
+			val, err := value.Val.Float64()
+			if err != nil {
+				continue
+			}
+			start, _ := time.Parse("2006-01-02", value.Start)
+			end, _ := time.Parse("2006-01-02", value.End)
+			filed, _ := time.Parse("2006-01-02", value.Filed)
+			values = append(values, periodValue{start: start, end: end, value: val, fp: value.FP, filed: filed})
+		}
+		if len(values) > 0 {
+			break
+		}
+	}
+	return values
+}
+
+// deriveQuartersFromYTD fills in discrete quarters that were only reported
+// year-to-date, as the difference between consecutive cumulative values
+func deriveQuartersFromYTD(quarters map[string]periodValue, ytd []periodValue) {
+	for _, current := range ytd {
+		key := current.end.Format("2006-01-02")
+		if _, exists := quarters[key]; exists {
+			continue
+		}
+
+		// The previous cumulative value shares the fiscal year start and ends latest before this one
+		var previous *periodValue
+		for i := range ytd {
+			candidate := &ytd[i]
+			if !candidate.start.Equal(current.start) || !candidate.end.Before(current.end) {
+				continue
+			}
+			if previous == nil || candidate.end.After(previous.end) {
+				previous = candidate
+			}
+		}
+		if previous == nil {
+			continue
+		}
+
+		gap := current.end.Sub(previous.end).Hours() / 24
+		if gap < quarterMinDays || gap > quarterMaxDays {
+			continue
+		}
+
+		quarters[key] = periodValue{
+			start: previous.end.AddDate(0, 0, 1),
+			end:   current.end,
+			value: current.value - previous.value,
+			fp:    current.fp,
+			filed: current.filed,
+		}
+	}
+}
+
+// deriveFourthQuarters fills in Q4 values, which are never filed on a 10-Q,
+// as the fiscal year total minus the three reported quarters
+func deriveFourthQuarters(quarters map[string]periodValue, annuals map[string]periodValue) {
+	for end, annual := range annuals {
+		if _, exists := quarters[end]; exists || annual.start.IsZero() {
+			continue
+		}
+
+		var inYear []periodValue
+		for _, q := range quarters {
+			if !q.start.Before(annual.start.AddDate(0, 0, -7)) && q.end.Before(annual.end) {
+				inYear = append(inYear, q)
+			}
+		}
+		if len(inYear) != 3 {
+			continue
+		}
+
+		sum := 0.0
+		lastEnd := inYear[0].end
+		for _, q := range inYear {
+			sum += q.value
+			if q.end.After(lastEnd) {
+				lastEnd = q.end
+			}
+		}
+
+		quarters[end] = periodValue{
+			start: lastEnd.AddDate(0, 0, 1),
+			end:   annual.end,
+			value: annual.value - sum,
+			fp:    "Q4",
+			filed: annual.filed,
+		}
+	}
+}
+
+// deriveFreeCashFlow sets FreeCashFlow = Operating Cash Flow - CapEx
+func deriveFreeCashFlow(statement *finance.FinancialStatement) {
+	if statement.OperatingCashFlow != 0 {
+		statement.FreeCashFlow = statement.OperatingCashFlow - statement.CapEx
+	}
+}
+
+// annualPeriodEnds returns the sorted fiscal year end dates seen in the anchor concepts
+func annualPeriodEnds(annualSeries []map[string]periodValue) []time.Time {
+	seen := make(map[string]bool)
+	var ends []time.Time
+	for i, concept := range statementConcepts {
+		if !concept.anchor {
+			continue
+		}
+		for end, pv := range annualSeries[i] {
+			if !seen[end] {
+				seen[end] = true
+				ends = append(ends, pv.end)
+			}
+		}
+	}
+	sort.Slice(ends, func(i, j int) bool { return ends[i].Before(ends[j]) })
+	return ends
+}
+
+// fiscalYearForQuarter maps a quarter end date to the fiscal year it belongs to.
+// Quarters after the last reported fiscal year are extrapolated from that year end.
+func fiscalYearForQuarter(end time.Time, fiscalYearEnds []time.Time) int {
+	for _, fyEnd := range fiscalYearEnds {
+		if !end.After(fyEnd) && end.After(fyEnd.AddDate(-1, 0, -7)) {
+			return fyEnd.Year()
+		}
+	}
+
+	if len(fiscalYearEnds) > 0 {
+		last := fiscalYearEnds[len(fiscalYearEnds)-1]
+		if end.After(last) {
+			yearsAhead := math.Ceil(end.Sub(last).Hours() / 24 / 366)
+			return last.Year() + int(yearsAhead)
+		}
+	}
+
+	return end.Year()
+}
+
+// quarterLabel normalizes an EDGAR fiscal period to Q1-Q4
+func quarterLabel(fp string) string {
+	switch fp {
+	case "Q1", "Q2", "Q3":
+		return fp
+	default:
+		return "Q4"
+	}
+}
+
+// getMockHistory generates a deterministic five-year history around the mock financials
+func (c *EDGARClient) getMockHistory(ticker string) *finance.HistoricalMetrics {
+	latest := c.getMockFinancials(ticker)
+	history := &finance.HistoricalMetrics{}
+
+	const years = 5
+	const annualGrowth = 0.06
+
+	for i := years - 1; i >= 0; i-- {
+		scale := 1 / math.Pow(1+annualGrowth, float64(i))
+		statement := scaleStatement(latest, scale)
+
+		// Earlier years had thinner margins, more debt and a larger asset base,
+		// so year-over-year comparisons have a real direction
+		margin := 1 - 0.015*float64(i)
+		statement.NetIncome *= margin
+		statement.EPS *= margin
+		statement.GrossProfit *= margin
+		statement.OperatingIncome *= margin
+		statement.PretaxIncome *= margin
+		statement.IncomeTaxExpense *= margin
+		statement.TotalDebt *= 1 + 0.04*float64(i)
+		statement.LongTermDebt *= 1 + 0.04*float64(i)
+		statement.TotalAssets *= 1 + 0.01*float64(i)
+		statement.CurrentLiabilities *= 1 - 0.02*float64(i)
+
+		statement.FiscalYear = latest.FiscalYear - i
+		statement.Period = fmt.Sprintf("%d-FY", statement.FiscalYear)
+		statement.PeriodBasis = finance.BasisFY
+		statement.ReportDate = latest.ReportDate.AddDate(-i, 0, 0)
+		statement.FilingDate = latest.FilingDate.AddDate(-i, 0, 0)
+		history.AnnualStatements = append(history.AnnualStatements, statement)
+	}
+
+	// Eight quarters with a mild holiday-quarter seasonality (weights sum to 1)
+	seasonality := []float64{0.30, 0.24, 0.22, 0.24}
+	for _, annual := range history.AnnualStatements[years-2:] {
+		for q, weight := range seasonality {
+			statement := scaleStatement(&annual, weight)
+			// Balance sheet items are point-in-time, not flows
+			statement.TotalAssets = annual.TotalAssets
+			statement.CurrentAssets = annual.CurrentAssets
+			statement.AccountsReceivable = annual.AccountsReceivable
+			statement.PropertyPlantEquipment = annual.PropertyPlantEquipment
+			statement.TotalLiabilities = annual.TotalLiabilities
+			statement.CurrentLiabilities = annual.CurrentLiabilities
+			statement.TotalDebt = annual.TotalDebt
+			statement.LongTermDebt = annual.LongTermDebt
+			statement.ShareholdersEquity = annual.ShareholdersEquity
+			statement.RetainedEarnings = annual.RetainedEarnings
+			statement.Cash = annual.Cash
+			statement.ShortTermInvestments = annual.ShortTermInvestments
+			statement.MinorityInterest = annual.MinorityInterest
+			statement.PreferredEquity = annual.PreferredEquity
+			statement.FiscalYear = annual.FiscalYear
+			statement.Period = fmt.Sprintf("%d-Q%d", annual.FiscalYear, q+1)
+			statement.PeriodBasis = finance.BasisQuarter
+			statement.ReportDate = annual.ReportDate.AddDate(0, -3*(3-q), 0)
+			statement.FilingDate = statement.ReportDate.AddDate(0, 1, 0)
+			history.QuarterlyStatements = append(history.QuarterlyStatements, statement)
+		}
+	}
+
+	return history
+}
+
+// scaleStatement returns a copy of a statement with every amount multiplied by scale
+func scaleStatement(s *finance.FinancialStatement, scale float64) finance.FinancialStatement {
+	scaled := *s
+	scaled.Revenue *= scale
+	scaled.NetIncome *= scale
+	scaled.EPS *= scale
+	scaled.DividendsPerShare *= scale
+	scaled.GrossProfit *= scale
+	scaled.OperatingIncome *= scale
+	scaled.DepreciationAmortization *= scale
+	scaled.SGAExpense *= scale
+	scaled.InterestExpense *= scale
+	scaled.IncomeTaxExpense *= scale
+	scaled.PretaxIncome *= scale
+	scaled.TotalAssets *= scale
+	scaled.CurrentAssets *= scale
+	scaled.AccountsReceivable *= scale
+	scaled.PropertyPlantEquipment *= scale
+	scaled.TotalLiabilities *= scale
+	scaled.CurrentLiabilities *= scale
+	scaled.TotalDebt *= scale
+	scaled.LongTermDebt *= scale
+	scaled.ShareholdersEquity *= scale
+	scaled.RetainedEarnings *= scale
+	scaled.Cash *= scale
+	scaled.ShortTermInvestments *= scale
+	scaled.MinorityInterest *= scale
+	scaled.PreferredEquity *= scale
+	scaled.OperatingCashFlow *= scale
+	scaled.CapEx *= scale
+	scaled.FreeCashFlow *= scale
+	return scaled
+}
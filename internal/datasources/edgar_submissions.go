@@ -0,0 +1,102 @@
+package datasources
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// EDGAR submissions response (only the classification fields are decoded)
+type edgarSubmissions struct {
+	CIK            string `json:"cik"`
+	Name           string `json:"name"`
+	SIC            string `json:"sic"`
+	SICDescription string `json:"sicDescription"`
+}
+
+// mockSICCodes classifies well-known tickers in mock mode; everything else is a tech company
+var mockSICCodes = map[string]finance.IndustryClassification{
+	"JPM": {SIC: "6021", SICDescription: "National Commercial Banks"},
+	"BAC": {SIC: "6021", SICDescription: "National Commercial Banks"},
+	"WFC": {SIC: "6021", SICDescription: "National Commercial Banks"},
+	"C":   {SIC: "6021", SICDescription: "National Commercial Banks"},
+	"GS":  {SIC: "6211", SICDescription: "Security Brokers, Dealers & Flotation Companies"},
+	"MS":  {SIC: "6211", SICDescription: "Security Brokers, Dealers & Flotation Companies"},
+	"AIG": {SIC: "6331", SICDescription: "Fire, Marine & Casualty Insurance"},
+	"XOM": {SIC: "2911", SICDescription: "Petroleum Refining"},
+	"JNJ": {SIC: "2834", SICDescription: "Pharmaceutical Preparations"},
+	"WMT": {SIC: "5331", SICDescription: "Retail-Variety Stores"},
+	"NEE": {SIC: "4911", SICDescription: "Electric Services"},
+	"DUK": {SIC: "4911", SICDescription: "Electric Services"},
+	"O":   {SIC: "6798", SICDescription: "Real Estate Investment Trusts"},
+	"PLD": {SIC: "6798", SICDescription: "Real Estate Investment Trusts"},
+}
+
+// GetIndustryClassification fetches a company's SIC code from the EDGAR submissions endpoint
+func (c *EDGARClient) GetIndustryClassification(ticker string) (*finance.IndustryClassification, error) {
+	if c.useMock {
+		return c.getMockIndustryClassification(ticker), nil
+	}
+
+	cik, err := c.getCIK(ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/submissions/CIK%s.json", edgarBaseURL, cik)
+
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, &finance.DataSourceError{
+			Source:  "EDGAR",
+			Message: fmt.Sprintf("failed to create request: %v", err),
+		}
+	}
+
+	// SEC requires User-Agent header
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, &finance.DataSourceError{
+			Source:  "EDGAR",
+			Message: fmt.Sprintf("failed to fetch submissions: %v", err),
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &finance.DataSourceError{
+			Source:  "EDGAR",
+			Message: fmt.Sprintf("API error (status %d)", resp.StatusCode),
+			Code:    fmt.Sprintf("%d", resp.StatusCode),
+		}
+	}
+
+	var submissions edgarSubmissions
+	if err := json.NewDecoder(resp.Body).Decode(&submissions); err != nil {
+		return nil, &finance.DataSourceError{
+			Source:  "EDGAR",
+			Message: fmt.Sprintf("failed to parse submissions: %v", err),
+		}
+	}
+
+	return &finance.IndustryClassification{
+		CIK:            cik,
+		SIC:            submissions.SIC,
+		SICDescription: submissions.SICDescription,
+	}, nil
+}
+
+// Mock data for testing
+func (c *EDGARClient) getMockIndustryClassification(ticker string) *finance.IndustryClassification {
+	classification, ok := mockSICCodes[strings.ToUpper(ticker)]
+	if !ok {
+		classification = finance.IndustryClassification{SIC: "3571", SICDescription: "Electronic Computers"}
+	}
+	return &classification
+}
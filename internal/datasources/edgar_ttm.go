@@ -0,0 +1,186 @@
+package datasources
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// ttmMinDays and ttmMaxDays bound the span of four consecutive quarters
+const (
+	ttmMinDays = 350
+	ttmMaxDays = 380
+)
+
+// buildTTMStatement builds the latest trailing-twelve-month statement.
+// Flow concepts (income and cash flow) sum the last four discrete quarters,
+// falling back to the latest fiscal year when a full chain is not available.
+// Balance sheet concepts take the most recent point-in-time value.
+func buildTTMStatement(usGAAP map[string]edgarFact) *finance.FinancialStatement {
+	annualSeries := conceptSeries(usGAAP, spanAnnual, nil)
+	quarterlySeries := conceptSeries(usGAAP, spanQuarterly, annualSeries)
+	fiscalYearEnds := annualPeriodEnds(annualSeries)
+
+	statement := &finance.FinancialStatement{
+		FieldBasis: make(map[string]finance.PeriodBasis),
+	}
+
+	// The TTM window ends at the latest quarter reported by an anchor concept
+	var latestQuarter periodValue
+	for i, concept := range statementConcepts {
+		if !concept.anchor {
+			continue
+		}
+		for _, pv := range quarterlySeries[i] {
+			if pv.end.After(latestQuarter.end) {
+				latestQuarter = pv
+			}
+		}
+	}
+
+	// Balance sheet values are all taken as of the latest balance sheet date so
+	// that a tag the company stopped using doesn't contribute a stale number
+	var balanceSheetDate time.Time
+	for i, concept := range statementConcepts {
+		if !concept.instant {
+			continue
+		}
+		for _, pv := range quarterlySeries[i] {
+			if pv.end.After(balanceSheetDate) {
+				balanceSheetDate = pv.end
+			}
+		}
+	}
+	balanceSheetKey := balanceSheetDate.Format("2006-01-02")
+	balanceSheetBasis := finance.BasisQuarter
+	for _, fyEnd := range fiscalYearEnds {
+		if fyEnd.Equal(balanceSheetDate) {
+			balanceSheetBasis = finance.BasisFY
+		}
+	}
+
+	anyTTM := false
+	for i, concept := range statementConcepts {
+		if concept.instant {
+			if pv, ok := quarterlySeries[i][balanceSheetKey]; ok {
+				concept.assign(statement, pv.value)
+				statement.FieldBasis[concept.field] = balanceSheetBasis
+				if pv.filed.After(statement.FilingDate) {
+					statement.FilingDate = pv.filed
+				}
+			}
+			continue
+		}
+
+		value, basis, filed, ok := trailingValue(quarterlySeries[i], annualSeries[i], latestQuarter.end, fiscalYearEnds)
+		if !ok {
+			continue
+		}
+		concept.assign(statement, value)
+		statement.FieldBasis[concept.field] = basis
+		if basis == finance.BasisTTM {
+			anyTTM = true
+		}
+		if filed.After(statement.FilingDate) {
+			statement.FilingDate = filed
+		}
+	}
+
+	deriveFreeCashFlow(statement)
+	if basis, ok := statement.FieldBasis["operating_cash_flow"]; ok {
+		statement.FieldBasis["free_cash_flow"] = basis
+	}
+
+	if len(statement.FieldBasis) == 0 {
+		statement.FieldBasis = nil
+		return statement
+	}
+
+	statement.ReportDate = latestQuarter.end
+	if balanceSheetDate.After(statement.ReportDate) {
+		statement.ReportDate = balanceSheetDate
+	}
+
+	if anyTTM {
+		statement.FiscalYear = fiscalYearForQuarter(latestQuarter.end, fiscalYearEnds)
+		statement.Period = fmt.Sprintf("%d-%s-TTM", statement.FiscalYear, quarterLabel(latestQuarter.fp))
+		statement.PeriodBasis = finance.BasisTTM
+	} else {
+		statement.FiscalYear = statement.ReportDate.Year()
+		if len(fiscalYearEnds) > 0 {
+			statement.FiscalYear = fiscalYearEnds[len(fiscalYearEnds)-1].Year()
+		}
+		statement.Period = fmt.Sprintf("%d-FY", statement.FiscalYear)
+		statement.PeriodBasis = finance.BasisFY
+	}
+
+	return statement
+}
+
+// trailingValue returns a flow concept's trailing-twelve-month value ending at windowEnd.
+// When the latest quarter closes a fiscal year the 10-K total is used directly (basis FY);
+// when four consecutive quarters are not available the latest fiscal year is used instead.
+func trailingValue(quarters, annuals map[string]periodValue, windowEnd time.Time, fiscalYearEnds []time.Time) (float64, finance.PeriodBasis, time.Time, bool) {
+	windowKey := windowEnd.Format("2006-01-02")
+	if annual, ok := annuals[windowKey]; ok {
+		return annual.value, finance.BasisFY, annual.filed, true
+	}
+
+	if sum, filed, ok := sumLastFourQuarters(quarters, windowEnd); ok {
+		return sum, finance.BasisTTM, filed, true
+	}
+
+	for i := len(fiscalYearEnds) - 1; i >= 0; i-- {
+		if annual, ok := annuals[fiscalYearEnds[i].Format("2006-01-02")]; ok {
+			return annual.value, finance.BasisFY, annual.filed, true
+		}
+	}
+
+	return 0, "", time.Time{}, false
+}
+
+// sumLastFourQuarters sums the four discrete quarters ending at windowEnd,
+// requiring them to be consecutive and together span roughly one year
+func sumLastFourQuarters(quarters map[string]periodValue, windowEnd time.Time) (float64, time.Time, bool) {
+	var chain []periodValue
+	for _, q := range quarters {
+		if !q.end.After(windowEnd) {
+			chain = append(chain, q)
+		}
+	}
+	if len(chain) < 4 {
+		return 0, time.Time{}, false
+	}
+
+	sort.Slice(chain, func(i, j int) bool { return chain[i].end.Before(chain[j].end) })
+	chain = chain[len(chain)-4:]
+
+	if !chain[3].end.Equal(windowEnd) {
+		return 0, time.Time{}, false
+	}
+
+	for i := 1; i < len(chain); i++ {
+		gap := chain[i].end.Sub(chain[i-1].end).Hours() / 24
+		if gap < quarterMinDays || gap > quarterMaxDays {
+			return 0, time.Time{}, false
+		}
+	}
+
+	span := chain[3].end.Sub(chain[0].start).Hours() / 24
+	if chain[0].start.IsZero() || span < ttmMinDays || span > ttmMaxDays {
+		return 0, time.Time{}, false
+	}
+
+	sum := 0.0
+	var filed time.Time
+	for _, q := range chain {
+		sum += q.value
+		if q.filed.After(filed) {
+			filed = q.filed
+		}
+	}
+
+	return sum, filed, true
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"time"
@@ -52,22 +53,41 @@ type finnhubProfileResponse struct {
 
 type finnhubMetricResponse struct {
 	Metric struct {
-		PE10YearHigh  float64 `json:"10DayAverageTradingVolume"`
-		WeekHigh52    float64 `json:"52WeekHigh"`
-		WeekLow52     float64 `json:"52WeekLow"`
-		Beta          float64 `json:"beta"`
-		PERatio       float64 `json:"peBasicExclExtraTTM"`
-		PEForward     float64 `json:"peNormalizedAnnual"`
-		PEGRatio      float64 `json:"peTTM"`
-		DividendYield float64 `json:"dividendYieldIndicatedAnnual"`
-		ROE           float64 `json:"roeTTM"`
-		ROA           float64 `json:"roaTTM"`
-		QuickRatio    float64 `json:"quickRatioAnnual"`
-		CurrentRatio  float64 `json:"currentRatioAnnual"`
-		DebtToEquity  float64 `json:"totalDebt/totalEquityAnnual"`
+		AverageVolume10Day float64 `json:"10DayAverageTradingVolume"` // Millions of shares
+		WeekHigh52         float64 `json:"52WeekHigh"`
+		WeekLow52          float64 `json:"52WeekLow"`
+		Beta               float64 `json:"beta"`
+		PERatio            float64 `json:"peBasicExclExtraTTM"`
+		PETTM              float64 `json:"peTTM"`
+		PEForward          float64 `json:"peNormalizedAnnual"`
+		DividendYield      float64 `json:"dividendYieldIndicatedAnnual"`
+		ROE                float64 `json:"roeTTM"` // Percentage
+		ROA                float64 `json:"roaTTM"` // Percentage
+		QuickRatio         float64 `json:"quickRatioAnnual"`
+		CurrentRatio       float64 `json:"currentRatioAnnual"`
+		DebtToEquity       float64 `json:"totalDebt/totalEquityAnnual"`
+		PriceToBook        float64 `json:"pbAnnual"`
+		EVToEBITDA         float64 `json:"evEbitdaTTM"`
+		EVToSales          float64 `json:"evRevenueTTM"`
 	} `json:"metric"`
 }
 
+// ReferenceMetrics extracts the ratios used to cross-check our EDGAR-derived metrics
+func (m *finnhubMetricResponse) ReferenceMetrics() *finance.ReferenceMetrics {
+	// peTTM includes extraordinary items, matching our net income based P/E
+	peRatio := m.Metric.PETTM
+	if peRatio == 0 {
+		peRatio = m.Metric.PERatio
+	}
+
+	return &finance.ReferenceMetrics{
+		Source:       "Finnhub",
+		PERatio:      peRatio,
+		ROE:          m.Metric.ROE,
+		DebtToEquity: m.Metric.DebtToEquity,
+	}
+}
+
 // NewFinnhubClient creates a new Finnhub API client
 func NewFinnhubClient() *FinnhubClient {
 	cfg := config.GetConfig()
@@ -228,6 +248,49 @@ func (c *FinnhubClient) GetMetrics(ticker string) (*finnhubMetricResponse, error
 	return &metrics, nil
 }
 
+// GetPeers fetches the tickers Finnhub classifies in the same industry.
+// The list may include the ticker itself.
+func (c *FinnhubClient) GetPeers(ticker string) ([]string, error) {
+	if c.useMock {
+		return c.getMockPeers(ticker), nil
+	}
+
+	endpoint := fmt.Sprintf("%s/stock/peers", finnhubBaseURL)
+	params := url.Values{}
+	params.Add("symbol", ticker)
+	params.Add("token", c.apiKey)
+
+	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
+
+	resp, err := c.httpClient.Get(fullURL)
+	if err != nil {
+		return nil, &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: fmt.Sprintf("failed to fetch peers: %v", err),
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: fmt.Sprintf("API error (status %d): %s", resp.StatusCode, string(body)),
+			Code:    fmt.Sprintf("%d", resp.StatusCode),
+		}
+	}
+
+	var peers []string
+	if err := json.NewDecoder(resp.Body).Decode(&peers); err != nil {
+		return nil, &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: fmt.Sprintf("failed to parse peers response: %v", err),
+		}
+	}
+
+	return peers, nil
+}
+
 // Mock data for testing without API keys
 func (c *FinnhubClient) getMockQuote(ticker string) *finance.StockQuote {
 	// Mock data for AAPL
@@ -256,14 +319,71 @@ func (c *FinnhubClient) getMockProfile(ticker string) *finnhubProfileResponse {
 		Country:   "US",
 		Currency:  "USD",
 		Exchange:  "NASDAQ",
+
+		FinnhubIndustry: mockIndustry(ticker),
 	}
 }
 
+// mockIndustries gives well-known tickers a Finnhub industry in mock mode; everything else is a tech company
+var mockIndustries = map[string]string{
+	"JPM": "Banking",
+	"BAC": "Banking",
+	"WFC": "Banking",
+	"C":   "Banking",
+	"GS":  "Financial Services",
+	"MS":  "Financial Services",
+	"AIG": "Insurance",
+	"XOM": "Energy",
+	"JNJ": "Pharmaceuticals",
+	"WMT": "Retail",
+	"NEE": "Utilities",
+	"DUK": "Utilities",
+	"O":   "Real Estate",
+	"PLD": "Real Estate",
+}
+
+func mockIndustry(ticker string) string {
+	if industry, ok := mockIndustries[ticker]; ok {
+		return industry
+	}
+	return "Technology"
+}
+
 func (c *FinnhubClient) getMockMetrics(ticker string) *finnhubMetricResponse {
 	metrics := &finnhubMetricResponse{}
 	metrics.Metric.PERatio = 28.5
+	metrics.Metric.PETTM = 29.2
 	metrics.Metric.PEForward = 26.2
-	metrics.Metric.ROE = 0.47
-	metrics.Metric.DebtToEquity = 0.85
+	metrics.Metric.ROE = 160.6 // Finnhub reports percentages
+	metrics.Metric.DebtToEquity = 1.87
+	metrics.Metric.Beta = 1.25
+
+	// Valuation multiples vary by ticker so mock peer sets have a spread
+	spread := mockTickerSpread(ticker)
+	metrics.Metric.PriceToBook = 45.0 * spread
+	metrics.Metric.EVToEBITDA = 21.0 * spread
+	metrics.Metric.EVToSales = 7.2 * spread
 	return metrics
 }
+
+func (c *FinnhubClient) getMockPeers(ticker string) []string {
+	return []string{ticker, "MSFT", "GOOGL", "DELL", "HPQ", "HPE", "SMCI"}
+}
+
+// mockTickerSpread maps a ticker to a deterministic factor between 0.6 and 1.4
+func mockTickerSpread(ticker string) float64 {
+	sum := 0
+	for _, ch := range ticker {
+		sum = sum*31 + int(ch)
+	}
+	return 0.6 + float64(sum%81)/100
+}
+
+// mockPriceAt generates a deterministic mock closing price for any date: roughly
+// 11% annual drift anchored at the mock quote price, with a gentle quarterly cycle
+func mockPriceAt(date time.Time) float64 {
+	anchor := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	years := date.Sub(anchor).Hours() / 24 / 365.25
+	days := date.Sub(anchor).Hours() / 24
+	return 175.43 * math.Exp(0.11*years) * (1 + 0.05*math.Sin(2*math.Pi*days/91))
+}
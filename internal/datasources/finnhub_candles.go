@@ -0,0 +1,240 @@
+package datasources
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+type finnhubCandleResponse struct {
+	C []float64 `json:"c"` // Close prices
+	H []float64 `json:"h"` // High prices
+	L []float64 `json:"l"` // Low prices
+	O []float64 `json:"o"` // Open prices
+	T []int64   `json:"t"` // Timestamps
+	V []float64 `json:"v"` // Volumes
+	S string    `json:"s"` // Status: "ok" or "no_data"
+}
+
+// GetCandles fetches OHLCV bars between from and to (inclusive) at a daily,
+// weekly or monthly resolution, oldest first
+func (c *FinnhubClient) GetCandles(ticker, resolution string, from, to time.Time) ([]finance.PriceCandle, error) {
+	if resolution != finance.ResolutionDaily && resolution != finance.ResolutionWeekly && resolution != finance.ResolutionMonthly {
+		return nil, &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: fmt.Sprintf("unsupported candle resolution %q", resolution),
+		}
+	}
+
+	if c.useMock {
+		return aggregateCandles(getMockDailyCandles(ticker, from, to), resolution), nil
+	}
+
+	endpoint := fmt.Sprintf("%s/stock/candle", finnhubBaseURL)
+	params := url.Values{}
+	params.Add("symbol", ticker)
+	params.Add("resolution", resolution)
+	params.Add("from", fmt.Sprintf("%d", from.Unix()))
+	params.Add("to", fmt.Sprintf("%d", to.Add(24*time.Hour).Unix()))
+	params.Add("token", c.apiKey)
+
+	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
+
+	resp, err := c.httpClient.Get(fullURL)
+	if err != nil {
+		return nil, &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: fmt.Sprintf("failed to fetch candles: %v", err),
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: fmt.Sprintf("API error (status %d): %s", resp.StatusCode, string(body)),
+			Code:    fmt.Sprintf("%d", resp.StatusCode),
+		}
+	}
+
+	var candleResp finnhubCandleResponse
+	if err := json.NewDecoder(resp.Body).Decode(&candleResp); err != nil {
+		return nil, &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: fmt.Sprintf("failed to parse candle response: %v", err),
+		}
+	}
+
+	n := len(candleResp.T)
+	if candleResp.S != "ok" || n == 0 || len(candleResp.C) != n || len(candleResp.O) != n ||
+		len(candleResp.H) != n || len(candleResp.L) != n || len(candleResp.V) != n {
+		return nil, &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: fmt.Sprintf("no price history available for %s", ticker),
+			Code:    "NO_DATA",
+		}
+	}
+
+	candles := make([]finance.PriceCandle, n)
+	for i := range candles {
+		candles[i] = finance.PriceCandle{
+			Date:   time.Unix(candleResp.T[i], 0).UTC(),
+			Open:   candleResp.O[i],
+			High:   candleResp.H[i],
+			Low:    candleResp.L[i],
+			Close:  candleResp.C[i],
+			Volume: candleResp.V[i],
+		}
+	}
+
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Date.Before(candles[j].Date)
+	})
+
+	return candles, nil
+}
+
+// GetClosingPrices returns the last daily close on or before each of the given dates.
+// Prices are aligned with dates; a date with no trading history gets 0.
+func (c *FinnhubClient) GetClosingPrices(ticker string, dates []time.Time) ([]float64, error) {
+	prices := make([]float64, len(dates))
+	if len(dates) == 0 {
+		return prices, nil
+	}
+
+	from, to := dates[0], dates[0]
+	for _, date := range dates {
+		if date.Before(from) {
+			from = date
+		}
+		if date.After(to) {
+			to = date
+		}
+	}
+	// Look back a week so dates falling on weekends/holidays still find a close
+	from = from.AddDate(0, 0, -7)
+
+	candles, err := c.GetCandles(ticker, finance.ResolutionDaily, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, date := range dates {
+		// Candles are ascending; find the last one at or before the end of that day
+		cutoff := date.Add(24 * time.Hour)
+		idx := sort.Search(len(candles), func(j int) bool { return !candles[j].Date.Before(cutoff) })
+		if idx > 0 {
+			prices[i] = candles[idx-1].Close
+		}
+	}
+
+	return prices, nil
+}
+
+// aggregateCandles rolls ascending daily bars up into weekly or monthly bars
+func aggregateCandles(daily []finance.PriceCandle, resolution string) []finance.PriceCandle {
+	if resolution == finance.ResolutionDaily {
+		return daily
+	}
+
+	periodKey := func(date time.Time) int {
+		if resolution == finance.ResolutionWeekly {
+			year, week := date.ISOWeek()
+			return year*100 + week
+		}
+		return date.Year()*100 + int(date.Month())
+	}
+
+	bars := []finance.PriceCandle{}
+	lastKey := -1
+	for _, day := range daily {
+		key := periodKey(day.Date)
+		if key != lastKey {
+			bars = append(bars, day)
+			lastKey = key
+			continue
+		}
+
+		bar := &bars[len(bars)-1]
+		bar.High = math.Max(bar.High, day.High)
+		bar.Low = math.Min(bar.Low, day.Low)
+		bar.Close = day.Close
+		bar.Volume += day.Volume
+	}
+	return bars
+}
+
+// getMockDailyCandles generates deterministic weekday bars following mockPriceAt,
+// with per-ticker daily noise so returns and indicators have something to measure
+func getMockDailyCandles(ticker string, from, to time.Time) []finance.PriceCandle {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	candles := []finance.PriceCandle{}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+
+		open := mockDailyClose(ticker, previousWeekday(day))
+		closePrice := mockDailyClose(ticker, day)
+		candles = append(candles, finance.PriceCandle{
+			Date:   day,
+			Open:   open,
+			High:   roundCents(math.Max(open, closePrice) * (1 + 0.008*math.Abs(mockNoise(ticker, day, "high")))),
+			Low:    roundCents(math.Min(open, closePrice) * (1 - 0.008*math.Abs(mockNoise(ticker, day, "low")))),
+			Close:  closePrice,
+			Volume: math.Round(55000000 * (1 + 0.35*mockNoise(ticker, day, "volume"))),
+		})
+	}
+	return candles
+}
+
+// mockMarketTicker is the mock market index; other tickers move with it scaled by their mock beta
+const mockMarketTicker = "SPY"
+
+// mockDailyClose is mockPriceAt with deterministic daily noise: a market-wide
+// component scaled by the ticker's mock beta plus a ticker-specific component
+func mockDailyClose(ticker string, day time.Time) float64 {
+	noise := 0.010 * mockBeta(ticker) * mockNoise(mockMarketTicker, day, "market")
+	if ticker != mockMarketTicker {
+		noise += 0.008 * mockNoise(ticker, day, "close")
+	}
+	return roundCents(mockPriceAt(day) * (1 + noise))
+}
+
+// mockBeta is 1.0 for the market and a deterministic 0.6-1.4 for everything else
+func mockBeta(ticker string) float64 {
+	if ticker == mockMarketTicker {
+		return 1.0
+	}
+	return mockTickerSpread(ticker)
+}
+
+func roundCents(price float64) float64 {
+	return math.Round(price*100) / 100
+}
+
+// mockNoise maps a ticker, day and purpose to a deterministic value in [-1, 1]
+func mockNoise(ticker string, day time.Time, purpose string) float64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s|%s|%s", ticker, day.Format("2006-01-02"), purpose)
+	return float64(h.Sum64()%20001)/10000 - 1
+}
+
+func previousWeekday(day time.Time) time.Time {
+	prev := day.AddDate(0, 0, -1)
+	for prev.Weekday() == time.Saturday || prev.Weekday() == time.Sunday {
+		prev = prev.AddDate(0, 0, -1)
+	}
+	return prev
+}
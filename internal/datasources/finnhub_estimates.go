@@ -0,0 +1,250 @@
+package datasources
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+type finnhubEPSEstimateResponse struct {
+	Data []struct {
+		EPSAvg         float64 `json:"epsAvg"`
+		EPSHigh        float64 `json:"epsHigh"`
+		EPSLow         float64 `json:"epsLow"`
+		NumberAnalysts int     `json:"numberAnalysts"`
+		Period         string  `json:"period"` // Fiscal period end, e.g. "2025-09-30"
+		Year           int     `json:"year"`
+	} `json:"data"`
+}
+
+type finnhubRevenueEstimateResponse struct {
+	Data []struct {
+		RevenueAvg     float64 `json:"revenueAvg"`
+		RevenueHigh    float64 `json:"revenueHigh"`
+		RevenueLow     float64 `json:"revenueLow"`
+		NumberAnalysts int     `json:"numberAnalysts"`
+		Period         string  `json:"period"`
+		Year           int     `json:"year"`
+	} `json:"data"`
+}
+
+type finnhubPriceTargetResponse struct {
+	LastUpdated  string  `json:"lastUpdated"`
+	TargetHigh   float64 `json:"targetHigh"`
+	TargetLow    float64 `json:"targetLow"`
+	TargetMean   float64 `json:"targetMean"`
+	TargetMedian float64 `json:"targetMedian"`
+}
+
+type finnhubRecommendationResponse []struct {
+	Period     string `json:"period"`
+	StrongBuy  int    `json:"strongBuy"`
+	Buy        int    `json:"buy"`
+	Hold       int    `json:"hold"`
+	Sell       int    `json:"sell"`
+	StrongSell int    `json:"strongSell"`
+}
+
+// GetEPSEstimates fetches annual consensus EPS estimates, ordered by fiscal year
+func (c *FinnhubClient) GetEPSEstimates(ticker string) ([]finance.EPSEstimate, error) {
+	if c.useMock {
+		return c.getMockEPSEstimates(ticker), nil
+	}
+
+	var estimateResp finnhubEPSEstimateResponse
+	if err := c.fetchEstimates("/stock/eps-estimate", ticker, "EPS estimates", &estimateResp); err != nil {
+		return nil, err
+	}
+
+	estimates := make([]finance.EPSEstimate, 0, len(estimateResp.Data))
+	for _, d := range estimateResp.Data {
+		estimates = append(estimates, finance.EPSEstimate{
+			FiscalYear:     estimateYear(d.Year, d.Period),
+			Period:         d.Period,
+			EPSAvg:         d.EPSAvg,
+			EPSHigh:        d.EPSHigh,
+			EPSLow:         d.EPSLow,
+			NumberAnalysts: d.NumberAnalysts,
+		})
+	}
+
+	sort.Slice(estimates, func(i, j int) bool {
+		return estimates[i].FiscalYear < estimates[j].FiscalYear
+	})
+
+	return estimates, nil
+}
+
+// GetRevenueEstimates fetches annual consensus revenue estimates, ordered by fiscal year
+func (c *FinnhubClient) GetRevenueEstimates(ticker string) ([]finance.RevenueEstimate, error) {
+	if c.useMock {
+		return c.getMockRevenueEstimates(ticker), nil
+	}
+
+	var estimateResp finnhubRevenueEstimateResponse
+	if err := c.fetchEstimates("/stock/revenue-estimate", ticker, "revenue estimates", &estimateResp); err != nil {
+		return nil, err
+	}
+
+	estimates := make([]finance.RevenueEstimate, 0, len(estimateResp.Data))
+	for _, d := range estimateResp.Data {
+		estimates = append(estimates, finance.RevenueEstimate{
+			FiscalYear:     estimateYear(d.Year, d.Period),
+			Period:         d.Period,
+			RevenueAvg:     d.RevenueAvg,
+			RevenueHigh:    d.RevenueHigh,
+			RevenueLow:     d.RevenueLow,
+			NumberAnalysts: d.NumberAnalysts,
+		})
+	}
+
+	sort.Slice(estimates, func(i, j int) bool {
+		return estimates[i].FiscalYear < estimates[j].FiscalYear
+	})
+
+	return estimates, nil
+}
+
+// GetPriceTarget fetches the analyst 12-month price target range
+func (c *FinnhubClient) GetPriceTarget(ticker string) (*finance.PriceTarget, error) {
+	if c.useMock {
+		return c.getMockPriceTarget(ticker), nil
+	}
+
+	var targetResp finnhubPriceTargetResponse
+	if err := c.fetchEstimates("/stock/price-target", ticker, "price target", &targetResp); err != nil {
+		return nil, err
+	}
+
+	if targetResp.TargetMean <= 0 {
+		return nil, &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: "no price target coverage",
+		}
+	}
+
+	return &finance.PriceTarget{
+		High:        targetResp.TargetHigh,
+		Low:         targetResp.TargetLow,
+		Mean:        targetResp.TargetMean,
+		Median:      targetResp.TargetMedian,
+		LastUpdated: targetResp.LastUpdated,
+	}, nil
+}
+
+// GetRecommendationTrend fetches the most recent month of analyst ratings
+func (c *FinnhubClient) GetRecommendationTrend(ticker string) (*finance.RecommendationTrend, error) {
+	if c.useMock {
+		return c.getMockRecommendationTrend(ticker), nil
+	}
+
+	var trends finnhubRecommendationResponse
+	if err := c.fetchEstimates("/stock/recommendation", ticker, "recommendations", &trends); err != nil {
+		return nil, err
+	}
+
+	if len(trends) == 0 {
+		return nil, &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: "no analyst recommendations",
+		}
+	}
+
+	// Finnhub returns the latest period first
+	latest := trends[0]
+	return &finance.RecommendationTrend{
+		Period:     latest.Period,
+		StrongBuy:  latest.StrongBuy,
+		Buy:        latest.Buy,
+		Hold:       latest.Hold,
+		Sell:       latest.Sell,
+		StrongSell: latest.StrongSell,
+	}, nil
+}
+
+// fetchEstimates calls an analyst endpoint (annual frequency where supported) and decodes the response
+func (c *FinnhubClient) fetchEstimates(path, ticker, what string, target interface{}) error {
+	params := url.Values{}
+	params.Add("symbol", ticker)
+	params.Add("freq", "annual")
+	params.Add("token", c.apiKey)
+
+	fullURL := fmt.Sprintf("%s%s?%s", finnhubBaseURL, path, params.Encode())
+
+	resp, err := c.httpClient.Get(fullURL)
+	if err != nil {
+		return &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: fmt.Sprintf("failed to fetch %s: %v", what, err),
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: fmt.Sprintf("API error (status %d): %s", resp.StatusCode, string(body)),
+			Code:    fmt.Sprintf("%d", resp.StatusCode),
+		}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return &finance.DataSourceError{
+			Source:  "Finnhub",
+			Message: fmt.Sprintf("failed to parse %s response: %v", what, err),
+		}
+	}
+
+	return nil
+}
+
+// estimateYear returns the fiscal year, parsing it from the period end date when missing
+func estimateYear(year int, period string) int {
+	if year == 0 && len(period) >= 4 {
+		year, _ = strconv.Atoi(period[:4])
+	}
+	return year
+}
+
+func (c *FinnhubClient) getMockEPSEstimates(ticker string) []finance.EPSEstimate {
+	// Two years of consensus growing ~9% a year from the mock FY2024 EPS of 6.06
+	return []finance.EPSEstimate{
+		{FiscalYear: 2025, Period: "2025-09-30", EPSAvg: 6.61, EPSHigh: 6.95, EPSLow: 6.20, NumberAnalysts: 38},
+		{FiscalYear: 2026, Period: "2026-09-30", EPSAvg: 7.20, EPSHigh: 7.80, EPSLow: 6.55, NumberAnalysts: 35},
+	}
+}
+
+func (c *FinnhubClient) getMockRevenueEstimates(ticker string) []finance.RevenueEstimate {
+	// Two years of consensus growing ~6% a year from the mock FY2024 revenue of $394B
+	return []finance.RevenueEstimate{
+		{FiscalYear: 2025, Period: "2025-09-30", RevenueAvg: 418.0e9, RevenueHigh: 430.0e9, RevenueLow: 405.0e9, NumberAnalysts: 36},
+		{FiscalYear: 2026, Period: "2026-09-30", RevenueAvg: 443.1e9, RevenueHigh: 465.0e9, RevenueLow: 420.0e9, NumberAnalysts: 33},
+	}
+}
+
+func (c *FinnhubClient) getMockPriceTarget(ticker string) *finance.PriceTarget {
+	return &finance.PriceTarget{
+		High:        250.00,
+		Low:         165.00,
+		Mean:        210.50,
+		Median:      212.00,
+		LastUpdated: "2024-12-15 00:00:00",
+	}
+}
+
+func (c *FinnhubClient) getMockRecommendationTrend(ticker string) *finance.RecommendationTrend {
+	return &finance.RecommendationTrend{
+		Period:    "2024-12-01",
+		StrongBuy: 12,
+		Buy:       22,
+		Hold:      9,
+		Sell:      1,
+	}
+}
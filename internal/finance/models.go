@@ -18,6 +18,15 @@ type StockQuote struct {
 	Timestamp     time.Time `json:"timestamp"`
 }
 
+// PeriodBasis describes the period a reported number covers
+type PeriodBasis string
+
+const (
+	BasisTTM     PeriodBasis = "TTM"     // Sum of the last four discrete quarters
+	BasisFY      PeriodBasis = "FY"      // Full fiscal year (10-K)
+	BasisQuarter PeriodBasis = "QUARTER" // Single fiscal quarter (10-Q)
+)
+
 // FinancialStatement represents a company's financial data
 type FinancialStatement struct {
 	// Income Statement
@@ -25,11 +34,34 @@ type FinancialStatement struct {
 	NetIncome float64 `json:"net_income"`
 	EPS       float64 `json:"eps,omitempty"`
 
+	DividendsPerShare float64 `json:"dividends_per_share,omitempty"` // Declared per common share
+
+	GrossProfit              float64 `json:"gross_profit,omitempty"`
+	OperatingIncome          float64 `json:"operating_income,omitempty"`          // EBIT
+	DepreciationAmortization float64 `json:"depreciation_amortization,omitempty"` // From the cash flow statement
+
+	SGAExpense       float64 `json:"sga_expense,omitempty"` // Selling, general and administrative
+	InterestExpense  float64 `json:"interest_expense,omitempty"`
+	IncomeTaxExpense float64 `json:"income_tax_expense,omitempty"`
+	PretaxIncome     float64 `json:"pretax_income,omitempty"`
+
 	// Balance Sheet
-	TotalAssets        float64 `json:"total_assets"`
-	TotalLiabilities   float64 `json:"total_liabilities"`
-	TotalDebt          float64 `json:"total_debt"`
-	ShareholdersEquity float64 `json:"shareholders_equity"`
+	TotalAssets            float64 `json:"total_assets"`
+	CurrentAssets          float64 `json:"current_assets,omitempty"`
+	AccountsReceivable     float64 `json:"accounts_receivable,omitempty"`
+	PropertyPlantEquipment float64 `json:"property_plant_equipment,omitempty"` // Net of depreciation
+	TotalLiabilities       float64 `json:"total_liabilities"`
+	CurrentLiabilities     float64 `json:"current_liabilities,omitempty"`
+	TotalDebt              float64 `json:"total_debt"`
+	LongTermDebt           float64 `json:"long_term_debt,omitempty"` // Excludes current maturities
+	ShareholdersEquity     float64 `json:"shareholders_equity"`
+	RetainedEarnings       float64 `json:"retained_earnings,omitempty"` // Negative for an accumulated deficit
+
+	// Cash and Claims (for the enterprise-to-equity value bridge)
+	Cash                 float64 `json:"cash,omitempty"`
+	ShortTermInvestments float64 `json:"short_term_investments,omitempty"`
+	MinorityInterest     float64 `json:"minority_interest,omitempty"`
+	PreferredEquity      float64 `json:"preferred_equity,omitempty"`
 
 	// Cash Flow
 	OperatingCashFlow float64 `json:"operating_cash_flow"`
@@ -37,18 +69,29 @@ type FinancialStatement struct {
 	FreeCashFlow      float64 `json:"free_cash_flow"`
 
 	// Metadata
-	Period     string    `json:"period"` // e.g., "2024-Q3", "2024"
-	FiscalYear int       `json:"fiscal_year"`
-	ReportDate time.Time `json:"report_date"`
-	FilingDate time.Time `json:"filing_date,omitempty"`
+	Period      string                 `json:"period"` // e.g., "2024-Q3", "2024-FY", "2025-Q1-TTM"
+	PeriodBasis PeriodBasis            `json:"period_basis,omitempty"`
+	FieldBasis  map[string]PeriodBasis `json:"field_basis,omitempty"` // Per-field basis when a statement mixes periods
+	FiscalYear  int                    `json:"fiscal_year"`
+	ReportDate  time.Time              `json:"report_date"`
+	FilingDate  time.Time              `json:"filing_date,omitempty"`
 }
 
 // HistoricalMetrics represents historical data for trend analysis
+// Ratio histories are aligned with FiscalYears (oldest first); 0 marks a year
+// where the ratio was not meaningful, e.g. negative earnings or no price data.
 type HistoricalMetrics struct {
-	PERatios        []float64 `json:"pe_ratios,omitempty"`
-	PERatioAvg5Year float64   `json:"pe_ratio_avg_5year,omitempty"`
-	ROEHistory      []float64 `json:"roe_history,omitempty"`
-	FCFYieldHistory []float64 `json:"fcf_yield_history,omitempty"`
+	FiscalYears         []int     `json:"fiscal_years,omitempty"`
+	PERatios            []float64 `json:"pe_ratios,omitempty"`
+	PERatioAvg5Year     float64   `json:"pe_ratio_avg_5year,omitempty"`
+	ROEHistory          []float64 `json:"roe_history,omitempty"`
+	FCFYieldHistory     []float64 `json:"fcf_yield_history,omitempty"`
+	DebtToEquityHistory []float64 `json:"debt_to_equity_history,omitempty"`
+	ROICHistory         []float64 `json:"roic_history,omitempty"`
+
+	// Statement series, ordered oldest first
+	AnnualStatements    []FinancialStatement `json:"annual_statements,omitempty"`
+	QuarterlyStatements []FinancialStatement `json:"quarterly_statements,omitempty"`
 }
 
 // MetricRating represents a colored rating for a financial metric
@@ -68,6 +111,10 @@ type FundamentalMetric struct {
 	Rating      MetricRating `json:"rating"`
 	Message     string       `json:"message"`
 	Available   bool         `json:"available"`
+	Profile     string       `json:"profile,omitempty"` // Sector threshold profile used for the rating, e.g. "utilities"
+
+	GrowthRate   *float64 `json:"growth_rate,omitempty"`   // EPS growth behind growth-based metrics, e.g. 0.09 for 9%
+	GrowthSource string   `json:"growth_source,omitempty"` // "analyst_estimates" or "historical_eps_cagr"
 }
 
 // FundamentalScorecard represents the "Big 5" fundamental metrics
@@ -81,6 +128,146 @@ type FundamentalScorecard struct {
 
 	OverallScore string `json:"overall_score"` // e.g., "4/5 metrics healthy"
 	Summary      string `json:"summary"`
+
+	RuleSet       string       `json:"rule_set"`       // Scorecard rule set, e.g. "big5"
+	WeightedScore float64      `json:"weighted_score"` // 0-100, weighted rating points of available metrics
+	RuleResults   []RuleResult `json:"rule_results"`
+
+	Quality           *QualitySection           `json:"quality,omitempty"`            // Multi-period quality checks, separate from the Big 5
+	CapitalEfficiency *CapitalEfficiencySection `json:"capital_efficiency,omitempty"` // ROIC, which buybacks don't distort like ROE
+	RedFlags          []RedFlag                 `json:"red_flags,omitempty"`          // Distress and earnings-manipulation screens
+	Reconciliation    []MetricReconciliation    `json:"reconciliation,omitempty"`     // Our metrics cross-checked against a data vendor
+}
+
+// MetricReconciliation compares one of our metrics with a data vendor's value
+type MetricReconciliation struct {
+	Metric            string  `json:"metric"` // Rule metric name, e.g. "pe_ratio"
+	Ours              float64 `json:"ours"`
+	Reference         float64 `json:"reference"`
+	Source            string  `json:"source"`
+	DifferencePercent float64 `json:"difference_percent"` // (ours - reference) / |reference| × 100
+	TolerancePercent  float64 `json:"tolerance_percent"`
+	Diverges          bool    `json:"diverges"`
+	Message           string  `json:"message"`
+}
+
+// CapitalEfficiencySection reports return on invested capital against the cost of capital.
+// ROIC = NOPAT / Invested Capital, where NOPAT = EBIT × (1 - Tax Rate) and
+// Invested Capital = Total Debt + Shareholders' Equity - Cash.
+type CapitalEfficiencySection struct {
+	ROIC           FundamentalMetric `json:"roic"`             // Percentage
+	WACC           float64           `json:"wacc,omitempty"`   // Percentage
+	ROICWACCSpread FundamentalMetric `json:"roic_wacc_spread"` // Percentage points
+	Moat           *MoatSignal       `json:"moat,omitempty"`
+}
+
+// MoatSignal flags a durable competitive advantage from consistently high ROIC
+type MoatSignal struct {
+	Signal         string       `json:"signal"`    // "moat", "no_moat" or "insufficient_history"
+	Threshold      float64      `json:"threshold"` // ROIC percentage a year must exceed
+	YearsAbove     int          `json:"years_above"`
+	YearsRequired  int          `json:"years_required"`
+	YearsAvailable int          `json:"years_available"`
+	FiscalYears    []int        `json:"fiscal_years"`
+	ROICHistory    []float64    `json:"roic_history"` // Percentages aligned with FiscalYears; 0 when not meaningful
+	Rating         MetricRating `json:"rating"`
+	Message        string       `json:"message"`
+}
+
+// RuleResult is one rule set metric's rating and its share of the weighted score
+type RuleResult struct {
+	Metric          string       `json:"metric"`
+	Weight          float64      `json:"weight"`
+	Value           float64      `json:"value"`
+	Rating          MetricRating `json:"rating"`
+	Points          float64      `json:"points"`           // Rating points before weighting
+	Available       bool         `json:"available"`        // Unavailable metrics are left out of the weighted score
+	ThresholdSource string       `json:"threshold_source"` // "rule_set" or "metric"
+}
+
+// RedFlag zones
+const (
+	ZoneSafe     = "safe"
+	ZoneGrey     = "grey"
+	ZoneDistress = "distress"
+
+	ZoneUnlikelyManipulator = "unlikely_manipulator"
+	ZoneLikelyManipulator   = "likely_manipulator"
+)
+
+// RedFlag is a screen that warns about bankruptcy risk or aggressive accounting
+type RedFlag struct {
+	Name       string             `json:"name"`              // "altman_z_score" or "beneish_m_score"
+	Variant    string             `json:"variant,omitempty"` // e.g., "original" or "non_manufacturer" for Altman
+	Score      float64            `json:"score"`
+	Zone       string             `json:"zone,omitempty"` // e.g., "safe", "grey", "distress"
+	Flagged    bool               `json:"flagged"`        // true when the score falls in the danger zone
+	Rating     MetricRating       `json:"rating"`
+	Message    string             `json:"message"`
+	Available  bool               `json:"available"`
+	Components map[string]float64 `json:"components,omitempty"`
+}
+
+// QualitySection groups quality scores that compare consecutive annual statements
+type QualitySection struct {
+	Piotroski *PiotroskiScore `json:"piotroski_f_score,omitempty"`
+}
+
+// PiotroskiScore is the nine-point Piotroski F-Score
+type PiotroskiScore struct {
+	Score          int             `json:"score"` // Tests passed (0-9)
+	AvailableTests int             `json:"available_tests"`
+	CurrentYear    int             `json:"current_year"`
+	PriorYear      int             `json:"prior_year"`
+	Rating         MetricRating    `json:"rating"`
+	Message        string          `json:"message"`
+	Tests          []PiotroskiTest `json:"tests"`
+}
+
+// PiotroskiTest is one binary F-Score test with the inputs it was computed from
+type PiotroskiTest struct {
+	Name        string             `json:"name"`     // e.g., "positive_roa"
+	Category    string             `json:"category"` // "profitability", "leverage_liquidity" or "operating_efficiency"
+	Description string             `json:"description"`
+	Passed      bool               `json:"passed"`
+	Available   bool               `json:"available"` // false when an input was missing (counts as not passed)
+	Inputs      map[string]float64 `json:"inputs"`
+}
+
+// DuPontAnalysis decomposes ROE into its drivers for each fiscal year.
+// Three-factor: ROE = Net Margin × Asset Turnover × Equity Multiplier.
+// Five-factor: ROE = Tax Burden × Interest Burden × Operating Margin × Asset Turnover × Equity Multiplier.
+type DuPontAnalysis struct {
+	Years   []DuPontYear  `json:"years"`  // Oldest first
+	Trends  []DuPontTrend `json:"trends"` // One series per factor, aligned with Years
+	Summary string        `json:"summary"`
+}
+
+// DuPontYear is one fiscal year's ROE decomposition (ratios, e.g. 0.25 for 25%)
+type DuPontYear struct {
+	FiscalYear       int     `json:"fiscal_year"`
+	ROE              float64 `json:"roe"`
+	NetMargin        float64 `json:"net_margin"`        // Net Income / Revenue
+	AssetTurnover    float64 `json:"asset_turnover"`    // Revenue / Total Assets
+	EquityMultiplier float64 `json:"equity_multiplier"` // Total Assets / Shareholders' Equity
+
+	FiveFactorAvailable bool    `json:"five_factor_available"`      // false when pretax income or EBIT is missing
+	TaxBurden           float64 `json:"tax_burden,omitempty"`       // Net Income / Pretax Income
+	InterestBurden      float64 `json:"interest_burden,omitempty"`  // Pretax Income / EBIT
+	OperatingMargin     float64 `json:"operating_margin,omitempty"` // EBIT / Revenue
+}
+
+// DuPontTrend is a factor's multi-year series and its contribution to the change in ROE
+type DuPontTrend struct {
+	Factor    string    `json:"factor"` // e.g., "net_margin"
+	Values    []float64 `json:"values"`
+	Change    float64   `json:"change"`    // Last value minus first value
+	Direction string    `json:"direction"` // "rising", "falling" or "stable"
+
+	// Share of the log change in ROE over the window explained by this factor.
+	// Net margin, asset turnover and equity multiplier sum to 1; tax burden,
+	// interest burden and operating margin split net margin's share.
+	ROEContribution float64 `json:"roe_contribution"`
 }
 
 // DCFAssumptions represents the inputs for DCF valuation
@@ -90,13 +277,44 @@ type DCFAssumptions struct {
 	FCFMargin          float64 `json:"fcf_margin"`           // e.g., 0.12 for 12%
 	DiscountRate       float64 `json:"discount_rate"`        // e.g., 0.10 for 10%
 	TerminalGrowthRate float64 `json:"terminal_growth_rate"` // e.g., 0.025 for 2.5%
-	ProjectionYears    int     `json:"projection_years"`     // typically 5
+	ProjectionYears    int     `json:"projection_years"`     // HighGrowthYears + FadeYears
 	Source             string  `json:"source"`               // "user_input", "analyst_consensus", "defaults"
+
+	// Stage structure
+	Mode                 string  `json:"mode"`                   // "three_stage" or "single_stage"
+	HighGrowthYears      int     `json:"high_growth_years"`      // Years at RevenueGrowthRate
+	FadeYears            int     `json:"fade_years"`             // Years fading to terminal values
+	TerminalProfitMargin float64 `json:"terminal_profit_margin"` // Profit margin at the end of the fade
+	TerminalFCFMargin    float64 `json:"terminal_fcf_margin"`    // FCF margin at the end of the fade
+
+	WACC *WACCBreakdown `json:"wacc,omitempty"` // Set when the discount rate was computed rather than supplied
+}
+
+// WACCBreakdown shows how the weighted average cost of capital was derived
+// Cost of Equity (CAPM) = Risk-Free Rate + Beta × Equity Risk Premium
+// WACC = E/(D+E) × Cost of Equity + D/(D+E) × Cost of Debt × (1 - Tax Rate)
+type WACCBreakdown struct {
+	RiskFreeRate       float64  `json:"risk_free_rate"`
+	Beta               float64  `json:"beta"`
+	EquityRiskPremium  float64  `json:"equity_risk_premium"`
+	CostOfEquity       float64  `json:"cost_of_equity"`
+	PreTaxCostOfDebt   float64  `json:"pre_tax_cost_of_debt"`
+	TaxRate            float64  `json:"tax_rate"`
+	AfterTaxCostOfDebt float64  `json:"after_tax_cost_of_debt"`
+	EquityValue        float64  `json:"equity_value"` // Market capitalization
+	DebtValue          float64  `json:"debt_value"`   // Book value of total debt
+	EquityWeight       float64  `json:"equity_weight"`
+	DebtWeight         float64  `json:"debt_weight"`
+	WACC               float64  `json:"wacc"`
+	Notes              []string `json:"notes,omitempty"` // Fallbacks applied for missing inputs
 }
 
 // DCFProjection represents a single year's projection
 type DCFProjection struct {
 	Year           int     `json:"year"`
+	Stage          string  `json:"stage"` // "high_growth" or "fade"
+	GrowthRate     float64 `json:"growth_rate"`
+	FCFMargin      float64 `json:"fcf_margin"`
 	Revenue        float64 `json:"revenue"`
 	NetIncome      float64 `json:"net_income"`
 	FreeCashFlow   float64 `json:"free_cash_flow"`
@@ -114,7 +332,221 @@ type ValuationResult struct {
 	Projections       []DCFProjection `json:"projections,omitempty"`
 	TerminalValue     float64         `json:"terminal_value,omitempty"`
 	EnterpriseValue   float64         `json:"enterprise_value,omitempty"`
+	EquityBridge      *EquityBridge   `json:"equity_bridge,omitempty"`
+	EquityValue       float64         `json:"equity_value,omitempty"`
 	SharesOutstanding float64         `json:"shares_outstanding,omitempty"`
+
+	ReverseDCF *ReverseDCFResult `json:"reverse_dcf,omitempty"`
+	MonteCarlo *MonteCarloResult `json:"monte_carlo,omitempty"`
+	DDM        *DDMResult        `json:"ddm,omitempty"`
+
+	ResidualIncome  *ResidualIncomeResult `json:"residual_income,omitempty"`
+	Comps           *CompsResult          `json:"comps,omitempty"`
+	SelectionReason string                `json:"selection_reason,omitempty"` // Why the model was chosen when not requested
+
+	AnalystConsensus *AnalystConsensus `json:"analyst_consensus,omitempty"` // Street view next to our fair value
+}
+
+// PeerMultiples holds one peer's valuation multiples (0 = not reported)
+type PeerMultiples struct {
+	Ticker      string  `json:"ticker"`
+	PERatio     float64 `json:"pe_ratio"`
+	EVToEBITDA  float64 `json:"ev_to_ebitda"`
+	EVToSales   float64 `json:"ev_to_sales"`
+	PriceToBook float64 `json:"price_to_book"`
+}
+
+// CompsResult details a relative valuation against peer multiples
+type CompsResult struct {
+	PeerSource    string          `json:"peer_source"` // e.g., "finnhub_peers"
+	Peers         []PeerMultiples `json:"peers"`
+	Multiples     []CompsMultiple `json:"multiples"`
+	FairValueLow  float64         `json:"fair_value_low"`  // At peer 25th percentile multiples
+	FairValueHigh float64         `json:"fair_value_high"` // At peer 75th percentile multiples
+}
+
+// CompsMultiple is the fair value implied by one peer multiple
+type CompsMultiple struct {
+	Name          string  `json:"name"` // "pe", "ev_ebitda", "ev_sales" or "pb"
+	PeerCount     int     `json:"peer_count"`
+	P25           float64 `json:"p25,omitempty"`
+	Median        float64 `json:"median,omitempty"`
+	P75           float64 `json:"p75,omitempty"`
+	SubjectMetric float64 `json:"subject_metric"` // EPS, EBITDA, revenue or book value per share
+	ImpliedLow    float64 `json:"implied_low,omitempty"`
+	ImpliedMid    float64 `json:"implied_mid,omitempty"`
+	ImpliedHigh   float64 `json:"implied_high,omitempty"`
+	Available     bool    `json:"available"`
+	Note          string  `json:"note,omitempty"`
+}
+
+// ResidualIncomeResult details a residual income (excess return) valuation
+// Value = Book Value per Share + Σ PV((ROE - Cost of Equity) × Beginning Book Value)
+type ResidualIncomeResult struct {
+	BookValuePerShare float64                    `json:"book_value_per_share"`
+	StartingROE       float64                    `json:"starting_roe"`
+	ROESource         string                     `json:"roe_source"` // "user_input" or "latest_financials"
+	CostOfEquity      float64                    `json:"cost_of_equity"`
+	PayoutRatio       float64                    `json:"payout_ratio"` // Share of earnings not added to book value
+	FadeYears         int                        `json:"fade_years"`   // Years for ROE to reach the cost of equity
+	Projections       []ResidualIncomeProjection `json:"projections"`
+	PVResidualIncome  float64                    `json:"pv_residual_income"`
+}
+
+// ResidualIncomeProjection represents a single year's per-share residual income projection
+type ResidualIncomeProjection struct {
+	Year               int     `json:"year"`
+	ROE                float64 `json:"roe"`
+	BeginningBookValue float64 `json:"beginning_book_value"`
+	EarningsPerShare   float64 `json:"earnings_per_share"`
+	ResidualIncome     float64 `json:"residual_income"`
+	DiscountFactor     float64 `json:"discount_factor"`
+	PresentValue       float64 `json:"present_value"`
+}
+
+// DDMResult details a dividend discount model valuation
+type DDMResult struct {
+	Mode                    string               `json:"mode"`                       // "two_stage" or "gordon"
+	CurrentDividendPerShare float64              `json:"current_dividend_per_share"` // D0
+	PayoutRatio             float64              `json:"payout_ratio,omitempty"`     // Dividends / EPS
+	CostOfEquity            float64              `json:"cost_of_equity"`
+	DividendGrowthRate      float64              `json:"dividend_growth_rate"` // High-stage growth (Gordon: perpetual growth)
+	GrowthSource            string               `json:"growth_source"`        // "user_input", "historical_cagr", "sustainable_growth", "terminal_growth"
+	TerminalGrowthRate      float64              `json:"terminal_growth_rate"`
+	HighGrowthYears         int                  `json:"high_growth_years,omitempty"`
+	Dividends               []DividendProjection `json:"dividends,omitempty"`
+	TerminalValue           float64              `json:"terminal_value,omitempty"` // Per share, at the end of the high-growth stage
+	PVTerminalValue         float64              `json:"pv_terminal_value,omitempty"`
+	Notes                   []string             `json:"notes,omitempty"`
+}
+
+// DividendProjection represents a single year's projected dividend
+type DividendProjection struct {
+	Year             int     `json:"year"`
+	DividendPerShare float64 `json:"dividend_per_share"`
+	DiscountFactor   float64 `json:"discount_factor"`
+	PresentValue     float64 `json:"present_value"`
+}
+
+// Distribution describes the uncertainty in one DCF assumption
+type Distribution struct {
+	Type   string   `json:"type"` // "normal", "triangular" or "uniform"
+	Mean   float64  `json:"mean,omitempty"`
+	StdDev float64  `json:"stddev,omitempty"`
+	Mode   *float64 `json:"mode,omitempty"`
+	Min    *float64 `json:"min,omitempty"` // Optional bounds for normal
+	Max    *float64 `json:"max,omitempty"`
+}
+
+// MonteCarloResult summarizes the fair value distribution from simulated DCFs
+type MonteCarloResult struct {
+	Simulations            int                     `json:"simulations"`
+	ValidSimulations       int                     `json:"valid_simulations"` // Excludes draws with discount rate <= terminal growth
+	Seed                   int64                   `json:"seed"`
+	Distributions          map[string]Distribution `json:"distributions"`
+	Percentiles            MonteCarloPercentiles   `json:"percentiles"`
+	Mean                   float64                 `json:"mean"`
+	StdDev                 float64                 `json:"stddev"`
+	ProbabilityUndervalued float64                 `json:"probability_undervalued"` // Share of simulations above the current price
+	Histogram              []HistogramBin          `json:"histogram"`
+}
+
+// MonteCarloPercentiles holds fair value per share percentiles
+type MonteCarloPercentiles struct {
+	P5  float64 `json:"p5"`
+	P25 float64 `json:"p25"`
+	P50 float64 `json:"p50"`
+	P75 float64 `json:"p75"`
+	P95 float64 `json:"p95"`
+}
+
+// HistogramBin counts simulated fair values in [Lower, Upper)
+type HistogramBin struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int     `json:"count"`
+}
+
+// ReverseDCFResult reports the growth rate implied by the current market price
+type ReverseDCFResult struct {
+	ImpliedGrowthRate  float64           `json:"implied_growth_rate"`   // Growth that equates fair value and price
+	BaseCaseGrowthRate float64           `json:"base_case_growth_rate"` // Growth used by the forward DCF
+	BaseCaseFairValue  float64           `json:"base_case_fair_value"`
+	GrowthGap          float64           `json:"growth_gap"` // Implied - base case; positive = market expects more
+	Diagnostics        SolverDiagnostics `json:"diagnostics"`
+}
+
+// SolverDiagnostics describes how a numerical root finder converged
+type SolverDiagnostics struct {
+	Method      string  `json:"method"`
+	Iterations  int     `json:"iterations"`
+	Converged   bool    `json:"converged"`
+	Residual    float64 `json:"residual"`  // Fair value minus price at the solution ($)
+	Tolerance   float64 `json:"tolerance"` // Growth rate tolerance
+	BracketLow  float64 `json:"bracket_low"`
+	BracketHigh float64 `json:"bracket_high"`
+}
+
+// EquityBridge shows how enterprise value is converted to equity value
+// Equity Value = Enterprise Value - Net Debt - Minority Interest - Preferred Equity
+type EquityBridge struct {
+	Cash                 float64 `json:"cash"`
+	ShortTermInvestments float64 `json:"short_term_investments"`
+	TotalDebt            float64 `json:"total_debt"`
+	NetDebt              float64 `json:"net_debt"` // Total Debt - Cash - Short-Term Investments
+	MinorityInterest     float64 `json:"minority_interest"`
+	PreferredEquity      float64 `json:"preferred_equity"`
+}
+
+// SensitivityResult is a grid of DCF fair values across two varied assumptions.
+// Cells are indexed [y][x], matching YAxis.Values and XAxis.Values.
+type SensitivityResult struct {
+	XAxis         SensitivityAxisValues `json:"x_axis"`
+	YAxis         SensitivityAxisValues `json:"y_axis"`
+	BaseFairValue float64               `json:"base_fair_value"`
+	CurrentPrice  float64               `json:"current_price"`
+	Assumptions   DCFAssumptions        `json:"assumptions"` // Base case
+	Cells         [][]SensitivityCell   `json:"cells"`
+}
+
+// SensitivityAxisValues lists the values tried for one assumption
+type SensitivityAxisValues struct {
+	Assumption string    `json:"assumption"` // e.g., "discount_rate", "terminal_growth"
+	Values     []float64 `json:"values"`
+}
+
+// SensitivityCell is the DCF outcome for one combination of assumptions
+type SensitivityCell struct {
+	X                 float64 `json:"x"`
+	Y                 float64 `json:"y"`
+	FairValuePerShare float64 `json:"fair_value_per_share"`
+	UpsidePercent     float64 `json:"upside_percent"`
+	Valid             bool    `json:"valid"` // false when discount rate <= terminal growth
+}
+
+// ScenarioAnalysis holds named DCF scenarios and their probability-weighted fair value
+type ScenarioAnalysis struct {
+	Scenarios             []ScenarioResult    `json:"scenarios"`
+	WeightedFairValue     float64             `json:"weighted_fair_value"`
+	CurrentPrice          float64             `json:"current_price"`
+	WeightedUpsidePercent float64             `json:"weighted_upside_percent"`
+	Source                string              `json:"source"`               // "user_input" or "default"
+	Dispersion            *ScenarioDispersion `json:"dispersion,omitempty"` // How default scenarios were spread
+}
+
+// ScenarioResult is one scenario's valuation and normalized probability
+type ScenarioResult struct {
+	Name        string           `json:"name"`
+	Probability float64          `json:"probability"`
+	Valuation   *ValuationResult `json:"valuation"`
+}
+
+// ScenarioDispersion describes the spread used to derive default bull and bear cases
+type ScenarioDispersion struct {
+	Source          string  `json:"source"` // "historical_dispersion" or "fixed_spread"
+	Years           int     `json:"years,omitempty"`
+	GrowthStdDev    float64 `json:"growth_stddev"`
+	FCFMarginStdDev float64 `json:"fcf_margin_stddev"`
 }
 
 // CompanyData represents aggregated company data from all sources
@@ -123,10 +555,88 @@ type CompanyData struct {
 	CompanyName       string              `json:"company_name"`
 	CIK               string              `json:"cik,omitempty"`
 	FIGI              string              `json:"figi,omitempty"`
+	SIC               string              `json:"sic,omitempty"` // Standard Industrial Classification code
+	SICDescription    string              `json:"sic_description,omitempty"`
+	Industry          string              `json:"industry,omitempty"` // Finnhub industry, e.g. "Technology"
 	Quote             *StockQuote         `json:"quote,omitempty"`
 	LatestFinancials  *FinancialStatement `json:"latest_financials,omitempty"`
 	HistoricalData    *HistoricalMetrics  `json:"historical_data,omitempty"`
 	SharesOutstanding float64             `json:"shares_outstanding,omitempty"`
+	Beta              float64             `json:"beta,omitempty"`
+	AnalystConsensus  *AnalystConsensus   `json:"analyst_consensus,omitempty"`
+	ReferenceMetrics  *ReferenceMetrics   `json:"reference_metrics,omitempty"`
+}
+
+// ReferenceMetrics are ratios a data vendor publishes, used to cross-check our EDGAR-derived values.
+// Zero means the vendor didn't report the metric.
+type ReferenceMetrics struct {
+	Source       string  `json:"source"`
+	PERatio      float64 `json:"pe_ratio"`
+	ROE          float64 `json:"roe"` // Percentage
+	DebtToEquity float64 `json:"debt_to_equity"`
+}
+
+// AnalystConsensus collects Wall Street estimates, price targets and ratings
+type AnalystConsensus struct {
+	PriceTarget      *PriceTarget         `json:"price_target,omitempty"`
+	Recommendation   *RecommendationTrend `json:"recommendation,omitempty"`
+	RevenueEstimates []RevenueEstimate    `json:"revenue_estimates,omitempty"` // Oldest fiscal year first
+	EPSEstimates     []EPSEstimate        `json:"eps_estimates,omitempty"`     // Oldest fiscal year first
+
+	RevenueGrowthRate *float64 `json:"revenue_growth_rate,omitempty"` // Annualized consensus revenue growth
+
+	// Our fair value relative to the mean price target, set when attached to a valuation
+	FairValueVsTargetPercent *float64 `json:"fair_value_vs_target_percent,omitempty"`
+}
+
+// PriceTarget is the range of analyst 12-month price targets
+type PriceTarget struct {
+	High          float64 `json:"high"`
+	Low           float64 `json:"low"`
+	Mean          float64 `json:"mean"`
+	Median        float64 `json:"median"`
+	UpsidePercent float64 `json:"upside_percent"` // Mean target vs current price
+	LastUpdated   string  `json:"last_updated,omitempty"`
+}
+
+// RecommendationTrend is the latest month's distribution of analyst ratings
+type RecommendationTrend struct {
+	Period     string  `json:"period"` // e.g., "2024-12-01"
+	StrongBuy  int     `json:"strong_buy"`
+	Buy        int     `json:"buy"`
+	Hold       int     `json:"hold"`
+	Sell       int     `json:"sell"`
+	StrongSell int     `json:"strong_sell"`
+	Total      int     `json:"total"`
+	MeanScore  float64 `json:"mean_score"` // 1 = strong buy ... 5 = strong sell
+	Consensus  string  `json:"consensus"`  // "strong_buy", "buy", "hold", "sell" or "strong_sell"
+}
+
+// RevenueEstimate is the analyst consensus revenue for one fiscal year
+type RevenueEstimate struct {
+	FiscalYear     int     `json:"fiscal_year"`
+	Period         string  `json:"period"`
+	RevenueAvg     float64 `json:"revenue_avg"`
+	RevenueHigh    float64 `json:"revenue_high"`
+	RevenueLow     float64 `json:"revenue_low"`
+	NumberAnalysts int     `json:"number_analysts"`
+}
+
+// EPSEstimate is the analyst consensus EPS for one fiscal year
+type EPSEstimate struct {
+	FiscalYear     int     `json:"fiscal_year"`
+	Period         string  `json:"period"` // Fiscal period end, e.g. "2025-09-30"
+	EPSAvg         float64 `json:"eps_avg"`
+	EPSHigh        float64 `json:"eps_high"`
+	EPSLow         float64 `json:"eps_low"`
+	NumberAnalysts int     `json:"number_analysts"`
+}
+
+// IndustryClassification identifies a company's industry from its SEC filings
+type IndustryClassification struct {
+	CIK            string `json:"cik,omitempty"`
+	SIC            string `json:"sic"`
+	SICDescription string `json:"sic_description"`
 }
 
 // StockAnalysisResponse represents the complete API response
@@ -137,10 +647,119 @@ type StockAnalysisResponse struct {
 	LastUpdated          time.Time             `json:"last_updated"`
 	FundamentalScorecard *FundamentalScorecard `json:"fundamental_scorecard,omitempty"`
 	Valuation            *ValuationResult      `json:"valuation,omitempty"`
+	Sensitivity          *SensitivityResult    `json:"sensitivity,omitempty"`
+	Scenarios            *ScenarioAnalysis     `json:"scenarios,omitempty"`
+	DuPont               *DuPontAnalysis       `json:"dupont,omitempty"`
+	Risk                 *RiskStatistics       `json:"risk,omitempty"`
 	Warnings             []string              `json:"warnings,omitempty"`
 	DataFreshness        map[string]string     `json:"data_freshness,omitempty"`
 }
 
+// FinancialHistoryResponse represents the multi-period financial statements API response
+type FinancialHistoryResponse struct {
+	Ticker      string               `json:"ticker"`
+	CompanyName string               `json:"company_name"`
+	Period      string               `json:"period"` // "annual" or "quarterly"
+	Statements  []FinancialStatement `json:"statements"`
+	LastUpdated time.Time            `json:"last_updated"`
+	Warnings    []string             `json:"warnings,omitempty"`
+}
+
+// Price candle resolutions, as accepted by Finnhub
+const (
+	ResolutionDaily   = "D"
+	ResolutionWeekly  = "W"
+	ResolutionMonthly = "M"
+)
+
+// PriceCandle is one OHLCV bar; Date is the first trading day of the bar
+type PriceCandle struct {
+	Date   time.Time `json:"date"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume float64   `json:"volume"`
+}
+
+// PriceHistoryResponse represents the historical prices API response
+type PriceHistoryResponse struct {
+	Ticker      string        `json:"ticker"`
+	Resolution  string        `json:"resolution"` // "D", "W" or "M"
+	From        string        `json:"from"`
+	To          string        `json:"to"`
+	Candles     []PriceCandle `json:"candles"`
+	LastUpdated time.Time     `json:"last_updated"`
+	Warnings    []string      `json:"warnings,omitempty"`
+}
+
+// RiskStatistics are return and risk measures from daily closes over trailing windows
+type RiskStatistics struct {
+	Benchmark    string       `json:"benchmark"`
+	RiskFreeRate float64      `json:"risk_free_rate"`
+	Windows      []RiskWindow `json:"windows"`
+}
+
+// RiskWindow holds the statistics for one trailing window. Rates are decimals (0.12 = 12%).
+type RiskWindow struct {
+	Period               string   `json:"period"` // "1y", "3y" or "5y"
+	StartDate            string   `json:"start_date,omitempty"`
+	EndDate              string   `json:"end_date,omitempty"`
+	TradingDays          int      `json:"trading_days"`
+	AnnualizedReturn     float64  `json:"annualized_return"`
+	AnnualizedVolatility float64  `json:"annualized_volatility"`
+	MaxDrawdown          float64  `json:"max_drawdown"` // Largest peak-to-trough decline, e.g. -0.31
+	SharpeRatio          *float64 `json:"sharpe_ratio,omitempty"`
+	SortinoRatio         *float64 `json:"sortino_ratio,omitempty"`
+	Beta                 *float64 `json:"beta,omitempty"` // Versus the benchmark, from daily returns
+	Available            bool     `json:"available"`
+	Message              string   `json:"message,omitempty"`
+}
+
+// TechnicalSignal is the direction an indicator points to
+type TechnicalSignal string
+
+const (
+	SignalBullish    TechnicalSignal = "bullish"
+	SignalBearish    TechnicalSignal = "bearish"
+	SignalNeutral    TechnicalSignal = "neutral"
+	SignalOverbought TechnicalSignal = "overbought"
+	SignalOversold   TechnicalSignal = "oversold"
+	SignalNA         TechnicalSignal = "n/a"
+)
+
+// TechnicalIndicator is one indicator reading with a plain-language interpretation
+type TechnicalIndicator struct {
+	Value      float64            `json:"value"`
+	Components map[string]float64 `json:"components,omitempty"` // e.g. MACD line, signal line and histogram
+	Signal     TechnicalSignal    `json:"signal"`
+	Message    string             `json:"message"`
+	Available  bool               `json:"available"`
+}
+
+// TechnicalIndicators holds the indicators computed from daily candles
+type TechnicalIndicators struct {
+	SMA50          TechnicalIndicator `json:"sma_50"`
+	SMA200         TechnicalIndicator `json:"sma_200"`
+	EMA20          TechnicalIndicator `json:"ema_20"`
+	RSI            TechnicalIndicator `json:"rsi_14"`
+	MACD           TechnicalIndicator `json:"macd"`
+	BollingerBands TechnicalIndicator `json:"bollinger_bands"`
+	Week52Range    TechnicalIndicator `json:"week_52_range"`
+	AverageVolume  TechnicalIndicator `json:"average_volume"`
+}
+
+// TechnicalAnalysisResponse represents the technical indicators API response
+type TechnicalAnalysisResponse struct {
+	Ticker      string               `json:"ticker"`
+	AsOf        string               `json:"as_of,omitempty"` // Date of the latest candle
+	LastClose   float64              `json:"last_close"`
+	Indicators  *TechnicalIndicators `json:"indicators,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	LastUpdated time.Time            `json:"last_updated"`
+	Warnings    []string             `json:"warnings,omitempty"`
+}
+
 // DataSourceError represents an error from a data source
 type DataSourceError struct {
 	Source  string `json:"source"`
@@ -53,10 +53,18 @@ func Handler(request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPRes
 	// Stock analysis routes (authentication required)
 	case strings.HasPrefix(path, "/api/stocks/") && strings.HasSuffix(path, "/fundamentals") && method == "GET":
 		return auth.RequireAuth(handleStockFundamentalsAuth)(request)
-	case strings.HasPrefix(path, "/api/stocks/") && strings.HasSuffix(path, "/valuation") && method == "GET":
+	case strings.HasPrefix(path, "/api/stocks/") && strings.HasSuffix(path, "/valuation/sensitivity") && method == "GET":
+		return auth.RequireAuth(handleValuationSensitivityAuth)(request)
+	case strings.HasPrefix(path, "/api/stocks/") && strings.HasSuffix(path, "/valuation") && (method == "GET" || method == "POST"):
 		return auth.RequireAuth(handleStockValuationAuth)(request)
 	case strings.HasPrefix(path, "/api/stocks/") && strings.HasSuffix(path, "/metrics") && method == "GET":
 		return auth.RequireAuth(handleStockMetricsAuth)(request)
+	case strings.HasPrefix(path, "/api/stocks/") && strings.HasSuffix(path, "/financials") && method == "GET":
+		return auth.RequireAuth(handleStockFinancialsAuth)(request)
+	case strings.HasPrefix(path, "/api/stocks/") && strings.HasSuffix(path, "/prices") && method == "GET":
+		return auth.RequireAuth(handleStockPricesAuth)(request)
+	case strings.HasPrefix(path, "/api/stocks/") && strings.HasSuffix(path, "/technicals") && method == "GET":
+		return auth.RequireAuth(handleStockTechnicalsAuth)(request)
 	default:
 		return notFound()
 	}
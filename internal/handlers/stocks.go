@@ -6,6 +6,7 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -13,8 +14,59 @@ import (
 	"github.com/sshetty/finEdSkywalker/internal/calculator"
 	"github.com/sshetty/finEdSkywalker/internal/datasources"
 	"github.com/sshetty/finEdSkywalker/internal/finance"
+	"github.com/sshetty/finEdSkywalker/internal/technicals"
 )
 
+// Valuation endpoint modes
+const (
+	valuationModeStandard   = "standard"    // Fair value from assumptions
+	valuationModeReverse    = "reverse"     // Growth implied by the current price
+	valuationModeMonteCarlo = "monte_carlo" // Fair value distribution from simulated assumptions
+	valuationModeScenarios  = "scenarios"   // Probability-weighted bull/base/bear cases
+)
+
+// Valuation models
+const (
+	valuationModelDCF = "dcf" // Discounted free cash flow (default)
+	valuationModelDDM = "ddm" // Dividend discount model for income stocks
+
+	valuationModelResidualIncome = "residual_income" // Book value and excess ROE, for financials
+	valuationModelComps          = "comps"           // Peer median multiples
+)
+
+// technicalsLookbackDays of calendar history (~275 trading days) cover the 200-day SMA and 52-week range
+const technicalsLookbackDays = 400
+
+// priceResolutions maps accepted resolution values to Finnhub resolutions
+var priceResolutions = map[string]string{
+	"d":       finance.ResolutionDaily,
+	"daily":   finance.ResolutionDaily,
+	"w":       finance.ResolutionWeekly,
+	"weekly":  finance.ResolutionWeekly,
+	"m":       finance.ResolutionMonthly,
+	"monthly": finance.ResolutionMonthly,
+}
+
+// maxComparablePeers caps the peer set (one metrics request per peer)
+const maxComparablePeers = 8
+
+// scenarioRequest is one named scenario in a POST /valuation body.
+// Unset assumptions fall back to the query string inputs and their defaults.
+type scenarioRequest struct {
+	Name           string   `json:"name"`
+	Probability    float64  `json:"probability"`
+	RevenueGrowth  *float64 `json:"revenue_growth"`
+	ProfitMargin   *float64 `json:"profit_margin"`
+	FCFMargin      *float64 `json:"fcf_margin"`
+	DiscountRate   *float64 `json:"discount_rate"`
+	TerminalGrowth *float64 `json:"terminal_growth"`
+}
+
+// valuationRequestBody is the optional JSON body of POST /valuation
+type valuationRequestBody struct {
+	Scenarios []scenarioRequest `json:"scenarios"`
+}
+
 // StockService aggregates data from multiple sources
 type StockService struct {
 	finnhub  *datasources.FinnhubClient
@@ -47,7 +99,7 @@ func (s *StockService) GetCompanyData(ticker string) (*finance.CompanyData, []st
 		companyData.Quote = quote
 	}
 
-	// 2. Get company profile for name and market cap
+	// 2. Get company profile for name, market cap and industry
 	profile, err := s.finnhub.GetProfile(ticker)
 	if err != nil {
 		warnings = append(warnings, fmt.Sprintf("Company profile unavailable: %v", err))
@@ -59,9 +111,20 @@ func (s *StockService) GetCompanyData(ticker string) (*finance.CompanyData, []st
 			companyData.Quote.MarketCap = profile.MarketCap * 1_000_000 // Convert to actual value
 		}
 		companyData.SharesOutstanding = profile.SharesOut // In millions
+		companyData.Industry = profile.FinnhubIndustry
+	}
+
+	// 3. Get basic financial metrics (beta for the CAPM cost of equity, ratios to cross-check ours)
+	metrics, err := s.finnhub.GetMetrics(ticker)
+	if err != nil {
+		log.Printf("Finnhub metrics error for %s: %v", ticker, err)
+		// Not adding to warnings as the WACC falls back to a market beta of 1.0
+	} else {
+		companyData.Beta = metrics.Metric.Beta
+		companyData.ReferenceMetrics = metrics.ReferenceMetrics()
 	}
 
-	// 3. Get fundamental data from SEC EDGAR
+	// 4. Get fundamental data from SEC EDGAR
 	financials, err := s.edgar.GetCompanyFacts(ticker)
 	if err != nil {
 		warnings = append(warnings, fmt.Sprintf("Fundamental data unavailable: %v", err))
@@ -70,7 +133,28 @@ func (s *StockService) GetCompanyData(ticker string) (*finance.CompanyData, []st
 		companyData.LatestFinancials = financials
 	}
 
-	// 4. Get FIGI mapping (optional)
+	// 5. Get industry classification (SIC code) from SEC EDGAR
+	classification, err := s.edgar.GetIndustryClassification(ticker)
+	if err != nil {
+		log.Printf("EDGAR submissions error for %s: %v", ticker, err)
+		// Not adding to warnings as the SIC code only refines model selection
+	} else {
+		companyData.CIK = classification.CIK
+		companyData.SIC = classification.SIC
+		companyData.SICDescription = classification.SICDescription
+	}
+
+	// 6. Get multi-year statement history from SEC EDGAR
+	history, err := s.edgar.GetFinancialHistory(ticker)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Historical financials unavailable: %v", err))
+		log.Printf("EDGAR history error for %s: %v", ticker, err)
+	} else {
+		companyData.HistoricalData = history
+		s.populateHistoricalRatios(ticker, companyData, &warnings)
+	}
+
+	// 7. Get FIGI mapping (optional)
 	figi, name, err := s.openfigi.MapTicker(ticker)
 	if err != nil {
 		log.Printf("OpenFIGI error for %s: %v", ticker, err)
@@ -90,6 +174,142 @@ func (s *StockService) GetCompanyData(ticker string) (*finance.CompanyData, []st
 	return companyData, warnings
 }
 
+// LoadAnalystConsensus fetches price targets, ratings and estimates into companyData.
+// Only scorecard and valuation requests use them, so GetCompanyData leaves them out.
+// Each piece is optional, so failures are only logged; the consensus stays nil
+// when nothing is available.
+func (s *StockService) LoadAnalystConsensus(companyData *finance.CompanyData) {
+	ticker := companyData.Ticker
+	consensus := &finance.AnalystConsensus{}
+
+	// The four endpoints are independent, so fetch them concurrently
+	var wg sync.WaitGroup
+	wg.Add(4)
+
+	go func() {
+		defer wg.Done()
+		target, err := s.finnhub.GetPriceTarget(ticker)
+		if err != nil {
+			log.Printf("Finnhub price target error for %s: %v", ticker, err)
+			return
+		}
+		consensus.PriceTarget = target
+	}()
+
+	go func() {
+		defer wg.Done()
+		recommendation, err := s.finnhub.GetRecommendationTrend(ticker)
+		if err != nil {
+			log.Printf("Finnhub recommendation error for %s: %v", ticker, err)
+			return
+		}
+		consensus.Recommendation = recommendation
+	}()
+
+	go func() {
+		defer wg.Done()
+		revenueEstimates, err := s.finnhub.GetRevenueEstimates(ticker)
+		if err != nil {
+			log.Printf("Finnhub revenue estimate error for %s: %v", ticker, err)
+			return
+		}
+		consensus.RevenueEstimates = revenueEstimates
+	}()
+
+	go func() {
+		defer wg.Done()
+		epsEstimates, err := s.finnhub.GetEPSEstimates(ticker)
+		if err != nil {
+			log.Printf("Finnhub EPS estimate error for %s: %v", ticker, err)
+			return
+		}
+		consensus.EPSEstimates = epsEstimates
+	}()
+
+	wg.Wait()
+
+	if consensus.PriceTarget == nil && consensus.Recommendation == nil &&
+		len(consensus.RevenueEstimates) == 0 && len(consensus.EPSEstimates) == 0 {
+		return
+	}
+	companyData.AnalystConsensus = consensus
+}
+
+// populateHistoricalRatios prices each recent fiscal year end and derives the historical
+// ratios. Without prices only P/E and FCF yield drop out; ROE, D/E and ROIC need none.
+func (s *StockService) populateHistoricalRatios(ticker string, companyData *finance.CompanyData, warnings *[]string) {
+	annual := companyData.HistoricalData.AnnualStatements
+	if len(annual) == 0 {
+		return
+	}
+	if len(annual) > calculator.HistoryYears {
+		annual = annual[len(annual)-calculator.HistoryYears:]
+	}
+
+	dates := make([]time.Time, len(annual))
+	for i, statement := range annual {
+		dates[i] = statement.ReportDate
+	}
+
+	yearEndPrices := make(map[int]float64, len(annual))
+	prices, err := s.finnhub.GetClosingPrices(ticker, dates)
+	if err != nil {
+		*warnings = append(*warnings, fmt.Sprintf("Historical prices unavailable, P/E and FCF yield history omitted: %v", err))
+		log.Printf("Finnhub candle error for %s: %v", ticker, err)
+	} else {
+		for i, statement := range annual {
+			yearEndPrices[statement.FiscalYear] = prices[i]
+		}
+	}
+
+	calculator.PopulateHistoricalMetrics(companyData, yearEndPrices)
+}
+
+// GetPriceHistory fetches OHLCV candles for a date range
+func (s *StockService) GetPriceHistory(ticker, resolution string, from, to time.Time) ([]finance.PriceCandle, error) {
+	return s.finnhub.GetCandles(ticker, resolution, from, to)
+}
+
+// GetPeerMultiples gathers valuation multiples for the company's Finnhub industry peers
+func (s *StockService) GetPeerMultiples(ticker string) ([]finance.PeerMultiples, []string) {
+	warnings := []string{}
+
+	peerTickers, err := s.finnhub.GetPeers(ticker)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Peer list unavailable: %v", err))
+		log.Printf("Finnhub peers error for %s: %v", ticker, err)
+		return nil, warnings
+	}
+
+	peers := []finance.PeerMultiples{}
+	for _, peer := range peerTickers {
+		peer = strings.ToUpper(peer)
+		if peer == strings.ToUpper(ticker) {
+			continue
+		}
+		if len(peers) == maxComparablePeers {
+			break
+		}
+
+		metrics, err := s.finnhub.GetMetrics(peer)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Multiples unavailable for peer %s: %v", peer, err))
+			log.Printf("Finnhub metrics error for peer %s: %v", peer, err)
+			continue
+		}
+
+		peers = append(peers, finance.PeerMultiples{
+			Ticker:      peer,
+			PERatio:     metrics.Metric.PERatio,
+			EVToEBITDA:  metrics.Metric.EVToEBITDA,
+			EVToSales:   metrics.Metric.EVToSales,
+			PriceToBook: metrics.Metric.PriceToBook,
+		})
+	}
+
+	return peers, warnings
+}
+
 // handleStockFundamentalsAuth is the authenticated version of handleStockFundamentals
 func handleStockFundamentalsAuth(request events.APIGatewayV2HTTPRequest, authCtx *auth.AuthContext) (events.APIGatewayV2HTTPResponse, error) {
 	// Extract ticker from path
@@ -114,12 +334,19 @@ func handleStockFundamentals(request events.APIGatewayV2HTTPRequest) (events.API
 
 	log.Printf("Fetching fundamentals for ticker: %s", ticker)
 
+	ruleSet, err := calculator.LoadRuleSet(request.QueryStringParameters["rule_set"])
+	if err != nil {
+		return errorResponse(400, "Invalid rule_set", err.Error())
+	}
+
 	// Get company data
 	service := NewStockService()
 	companyData, warnings := service.GetCompanyData(ticker)
+	service.LoadAnalystConsensus(companyData)
 
 	// Calculate scorecard
-	scorecard := calculator.CalculateScorecard(companyData)
+	scorecard := calculator.CalculateScorecard(companyData, ruleSet, nil)
+	warnings = append(warnings, calculator.ReconciliationWarnings(scorecard)...)
 
 	// Build response
 	response := finance.StockAnalysisResponse{
@@ -160,27 +387,263 @@ func handleStockValuation(request events.APIGatewayV2HTTPRequest) (events.APIGat
 	}
 	ticker := strings.ToUpper(parts[3])
 
+	mode := strings.ToLower(request.QueryStringParameters["mode"])
+	if mode != "" && mode != valuationModeStandard && mode != valuationModeReverse &&
+		mode != valuationModeMonteCarlo && mode != valuationModeScenarios {
+		return errorResponse(400, "Invalid request", "mode must be 'standard', 'reverse', 'monte_carlo' or 'scenarios'")
+	}
+
+	model := strings.ToLower(request.QueryStringParameters["model"])
+	if model != "" && model != valuationModelDCF && model != valuationModelDDM &&
+		model != valuationModelResidualIncome && model != valuationModelComps {
+		return errorResponse(400, "Invalid request", "model must be 'dcf', 'ddm', 'residual_income' or 'comps'")
+	}
+	if model != "" && model != valuationModelDCF &&
+		(request.RequestContext.HTTP.Method == "POST" || (mode != "" && mode != valuationModeStandard)) {
+		return errorResponse(400, "Invalid request", fmt.Sprintf("model=%s only supports the standard mode", model))
+	}
+
 	log.Printf("Calculating valuation for ticker: %s", ticker)
 
 	// Parse query parameters for DCF inputs
 	dcfInput := parseDCFInput(request.QueryStringParameters)
 
+	// A POST body carries user-defined scenarios
+	var scenarios []calculator.ScenarioInput
+	if request.RequestContext.HTTP.Method == "POST" {
+		var body valuationRequestBody
+		if err := parseJSONBody(request.Body, &body); err != nil {
+			return errorResponse(400, "Invalid request body", err.Error())
+		}
+		var err error
+		scenarios, err = buildScenarioInputs(body.Scenarios, dcfInput)
+		if err != nil {
+			return errorResponse(400, "Invalid request", err.Error())
+		}
+		mode = valuationModeScenarios
+	}
+
+	var monteCarlo calculator.MonteCarloInput
+	if mode == valuationModeMonteCarlo {
+		var err error
+		monteCarlo, err = parseMonteCarloInput(request.QueryStringParameters)
+		if err != nil {
+			return errorResponse(400, "Invalid request", err.Error())
+		}
+		monteCarlo.Base = dcfInput
+	}
+
 	// Get company data
 	service := NewStockService()
 	companyData, warnings := service.GetCompanyData(ticker)
+	service.LoadAnalystConsensus(companyData)
 
-	// Calculate DCF valuation
-	valuation, err := calculator.CalculateDCF(companyData, dcfInput)
+	// Build response
+	response := finance.StockAnalysisResponse{
+		Ticker:        ticker,
+		CompanyName:   companyData.CompanyName,
+		LastUpdated:   time.Now(),
+		Warnings:      warnings,
+		DataFreshness: buildDataFreshness(companyData),
+	}
+
+	if companyData.Quote != nil {
+		response.CurrentPrice = companyData.Quote.CurrentPrice
+	}
+
+	if mode == valuationModeScenarios {
+		analysis, err := calculateScenarioAnalysis(companyData, dcfInput, scenarios)
+		if err != nil {
+			return errorResponse(400, "Valuation failed", err.Error())
+		}
+		response.Scenarios = analysis
+		return jsonResponse(200, response)
+	}
+
+	// Banks and insurers are valued on book value and ROE unless a model was requested
+	selectionReason := ""
+	if model == "" && (mode == "" || mode == valuationModeStandard) && calculator.IsFinancialInstitution(companyData.SIC) {
+		model = valuationModelResidualIncome
+		selectionReason = fmt.Sprintf("Residual income model selected for a financial institution (SIC %s: %s)",
+			companyData.SIC, companyData.SICDescription)
+	}
+
+	// Calculate valuation (DDM, residual income, or DCF: forward, the growth implied by the price, or simulated)
+	var valuation *finance.ValuationResult
+	var err error
+	switch {
+	case model == valuationModelDDM:
+		valuation, err = calculator.CalculateDDM(companyData, parseDDMInput(request.QueryStringParameters, dcfInput))
+	case model == valuationModelResidualIncome:
+		valuation, err = calculator.CalculateResidualIncome(companyData, parseResidualIncomeInput(request.QueryStringParameters, dcfInput))
+	case model == valuationModelComps:
+		peers, peerWarnings := service.GetPeerMultiples(ticker)
+		response.Warnings = append(response.Warnings, peerWarnings...)
+		valuation, err = calculator.CalculateComps(companyData, peers, "finnhub_peers")
+	case mode == valuationModeReverse:
+		valuation, err = calculator.CalculateReverseDCF(companyData, dcfInput)
+	case mode == valuationModeMonteCarlo:
+		valuation, err = calculator.CalculateMonteCarlo(companyData, monteCarlo)
+	default:
+		valuation, err = calculator.CalculateDCF(companyData, dcfInput)
+	}
 	if err != nil {
 		return errorResponse(400, "Valuation failed", err.Error())
 	}
 
+	valuation.SelectionReason = selectionReason
+	calculator.AttachAnalystConsensus(valuation, companyData)
+	response.Valuation = valuation
+	return jsonResponse(200, response)
+}
+
+// calculateRiskStatistics fetches daily prices for the ticker and its benchmark and
+// computes the risk block. Without benchmark prices the windows omit beta.
+func calculateRiskStatistics(service *StockService, ticker, benchmark string, riskFreeRate *float64) (*finance.RiskStatistics, []string) {
+	to := time.Now().UTC()
+	from := calculator.RiskHistoryStart(to)
+
+	prices, err := service.GetPriceHistory(ticker, finance.ResolutionDaily, from, to)
+	if err != nil {
+		log.Printf("Finnhub candle error for %s: %v", ticker, err)
+		return nil, []string{fmt.Sprintf("Risk statistics unavailable: %v", err)}
+	}
+
+	warnings := []string{}
+	benchmarkPrices, err := service.GetPriceHistory(benchmark, finance.ResolutionDaily, from, to)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Benchmark %s prices unavailable, beta omitted: %v", benchmark, err))
+		log.Printf("Finnhub candle error for %s: %v", benchmark, err)
+		benchmarkPrices = nil
+	}
+
+	return calculator.CalculateRiskStatistics(prices, benchmarkPrices, benchmark, riskFreeRate), warnings
+}
+
+// calculateScenarioAnalysis values user-defined scenarios, or default bull/base/bear
+// scenarios derived from historical dispersion when none are supplied
+func calculateScenarioAnalysis(companyData *finance.CompanyData, base *calculator.DCFInput, scenarios []calculator.ScenarioInput) (*finance.ScenarioAnalysis, error) {
+	if len(scenarios) > 0 {
+		analysis, err := calculator.CalculateScenarios(companyData, scenarios)
+		if err != nil {
+			return nil, err
+		}
+		analysis.Source = "user_input"
+		return analysis, nil
+	}
+
+	defaults, dispersion, err := calculator.DefaultScenarios(companyData, base)
+	if err != nil {
+		return nil, err
+	}
+	analysis, err := calculator.CalculateScenarios(companyData, defaults)
+	if err != nil {
+		return nil, err
+	}
+	analysis.Source = "default"
+	analysis.Dispersion = dispersion
+	return analysis, nil
+}
+
+// buildScenarioInputs layers each scenario's assumptions over the query string inputs
+func buildScenarioInputs(requests []scenarioRequest, base *calculator.DCFInput) ([]calculator.ScenarioInput, error) {
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("scenarios must contain at least one scenario")
+	}
+
+	seen := make(map[string]bool)
+	scenarios := make([]calculator.ScenarioInput, 0, len(requests))
+	for i, req := range requests {
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			name = fmt.Sprintf("scenario_%d", i+1)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate scenario name %q", name)
+		}
+		seen[name] = true
+
+		input := *base
+		if req.RevenueGrowth != nil {
+			input.RevenueGrowthRate = req.RevenueGrowth
+		}
+		if req.ProfitMargin != nil {
+			input.ProfitMargin = req.ProfitMargin
+		}
+		if req.FCFMargin != nil {
+			input.FCFMargin = req.FCFMargin
+		}
+		if req.DiscountRate != nil {
+			input.DiscountRate = req.DiscountRate
+		}
+		if req.TerminalGrowth != nil {
+			input.TerminalGrowthRate = req.TerminalGrowth
+		}
+
+		scenarios = append(scenarios, calculator.ScenarioInput{
+			Name:        name,
+			Probability: req.Probability,
+			Input:       &input,
+		})
+	}
+
+	return scenarios, nil
+}
+
+// handleValuationSensitivityAuth is the authenticated version of handleValuationSensitivity
+func handleValuationSensitivityAuth(request events.APIGatewayV2HTTPRequest, authCtx *auth.AuthContext) (events.APIGatewayV2HTTPResponse, error) {
+	// Extract ticker from path
+	parts := strings.Split(request.RawPath, "/")
+	if len(parts) < 4 {
+		return errorResponse(400, "Invalid request", "Ticker symbol is required")
+	}
+	ticker := strings.ToUpper(parts[3])
+
+	log.Printf("User %s (%s) requesting valuation sensitivity for %s", authCtx.Username, authCtx.UserID, ticker)
+	return handleValuationSensitivity(request)
+}
+
+// handleValuationSensitivity returns a grid of DCF fair values across two assumptions
+// GET /api/stocks/{ticker}/valuation/sensitivity?x_axis=discount_rate&y_axis=terminal_growth
+func handleValuationSensitivity(request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Extract ticker from path
+	parts := strings.Split(request.RawPath, "/")
+	if len(parts) < 4 {
+		return errorResponse(400, "Invalid request", "Ticker symbol is required")
+	}
+	ticker := strings.ToUpper(parts[3])
+
+	params := request.QueryStringParameters
+	xAxis, err := parseSensitivityAxis(params, "x", calculator.SensitivityDiscountRate)
+	if err != nil {
+		return errorResponse(400, "Invalid request", err.Error())
+	}
+	yAxis, err := parseSensitivityAxis(params, "y", calculator.SensitivityTerminalGrowth)
+	if err != nil {
+		return errorResponse(400, "Invalid request", err.Error())
+	}
+
+	log.Printf("Calculating %s x %s sensitivity for ticker: %s", xAxis.Assumption, yAxis.Assumption, ticker)
+
+	// Parse query parameters for the base case DCF inputs
+	dcfInput := parseDCFInput(params)
+
+	// Get company data
+	service := NewStockService()
+	companyData, warnings := service.GetCompanyData(ticker)
+	service.LoadAnalystConsensus(companyData)
+
+	sensitivity, err := calculator.CalculateSensitivity(companyData, dcfInput, xAxis, yAxis)
+	if err != nil {
+		return errorResponse(400, "Sensitivity analysis failed", err.Error())
+	}
+
 	// Build response
 	response := finance.StockAnalysisResponse{
 		Ticker:        ticker,
 		CompanyName:   companyData.CompanyName,
 		LastUpdated:   time.Now(),
-		Valuation:     valuation,
+		Sensitivity:   sensitivity,
 		Warnings:      warnings,
 		DataFreshness: buildDataFreshness(companyData),
 	}
@@ -192,6 +655,34 @@ func handleStockValuation(request events.APIGatewayV2HTTPRequest) (events.APIGat
 	return jsonResponse(200, response)
 }
 
+// parseSensitivityAxis reads {prefix}_axis, {prefix}_min, {prefix}_max and {prefix}_step
+func parseSensitivityAxis(params map[string]string, prefix string, defaultAssumption string) (calculator.SensitivityAxis, error) {
+	axis := calculator.SensitivityAxis{Assumption: defaultAssumption}
+
+	if val, ok := params[prefix+"_axis"]; ok && val != "" {
+		axis.Assumption = strings.ToLower(val)
+	}
+
+	bounds := map[string]**float64{
+		prefix + "_min":  &axis.Min,
+		prefix + "_max":  &axis.Max,
+		prefix + "_step": &axis.Step,
+	}
+	for name, target := range bounds {
+		val, ok := params[name]
+		if !ok {
+			continue
+		}
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return axis, fmt.Errorf("%s must be a number", name)
+		}
+		*target = &f
+	}
+
+	return axis, nil
+}
+
 // handleStockMetricsAuth is the authenticated version of handleStockMetrics
 func handleStockMetricsAuth(request events.APIGatewayV2HTTPRequest, authCtx *auth.AuthContext) (events.APIGatewayV2HTTPResponse, error) {
 	// Extract ticker from path
@@ -216,15 +707,30 @@ func handleStockMetrics(request events.APIGatewayV2HTTPRequest) (events.APIGatew
 
 	log.Printf("Fetching comprehensive metrics for ticker: %s", ticker)
 
-	// Parse query parameters for DCF inputs
+	// Parse query parameters for DCF inputs and the scorecard rule set
 	dcfInput := parseDCFInput(request.QueryStringParameters)
+	ruleSet, err := calculator.LoadRuleSet(request.QueryStringParameters["rule_set"])
+	if err != nil {
+		return errorResponse(400, "Invalid rule_set", err.Error())
+	}
+
+	benchmark := strings.ToUpper(request.QueryStringParameters["benchmark"])
+	if benchmark == "" {
+		benchmark = calculator.DefaultRiskBenchmark
+	}
 
 	// Get company data
 	service := NewStockService()
 	companyData, warnings := service.GetCompanyData(ticker)
+	service.LoadAnalystConsensus(companyData)
 
 	// Calculate scorecard
-	scorecard := calculator.CalculateScorecard(companyData)
+	scorecard := calculator.CalculateScorecard(companyData, ruleSet, dcfInput)
+	warnings = append(warnings, calculator.ReconciliationWarnings(scorecard)...)
+
+	// Risk and return statistics from daily prices
+	risk, riskWarnings := calculateRiskStatistics(service, ticker, benchmark, dcfInput.RiskFreeRate)
+	warnings = append(warnings, riskWarnings...)
 
 	// Calculate DCF valuation
 	valuation, err := calculator.CalculateDCF(companyData, dcfInput)
@@ -232,6 +738,7 @@ func handleStockMetrics(request events.APIGatewayV2HTTPRequest) (events.APIGatew
 		warnings = append(warnings, fmt.Sprintf("Valuation calculation failed: %v", err))
 		log.Printf("DCF error for %s: %v", ticker, err)
 	}
+	calculator.AttachAnalystConsensus(valuation, companyData)
 
 	// Build comprehensive response
 	response := finance.StockAnalysisResponse{
@@ -240,10 +747,16 @@ func handleStockMetrics(request events.APIGatewayV2HTTPRequest) (events.APIGatew
 		LastUpdated:          time.Now(),
 		FundamentalScorecard: scorecard,
 		Valuation:            valuation,
+		DuPont:               calculator.CalculateDuPont(companyData),
+		Risk:                 risk,
 		Warnings:             warnings,
 		DataFreshness:        buildDataFreshness(companyData),
 	}
 
+	if response.DuPont == nil {
+		response.Warnings = append(response.Warnings, "DuPont analysis unavailable: no annual statements with positive revenue, assets and equity")
+	}
+
 	if companyData.Quote != nil {
 		response.CurrentPrice = companyData.Quote.CurrentPrice
 	}
@@ -251,6 +764,237 @@ func handleStockMetrics(request events.APIGatewayV2HTTPRequest) (events.APIGatew
 	return jsonResponse(200, response)
 }
 
+// handleStockFinancialsAuth is the authenticated version of handleStockFinancials
+func handleStockFinancialsAuth(request events.APIGatewayV2HTTPRequest, authCtx *auth.AuthContext) (events.APIGatewayV2HTTPResponse, error) {
+	// Extract ticker from path
+	parts := strings.Split(request.RawPath, "/")
+	if len(parts) < 4 {
+		return errorResponse(400, "Invalid request", "Ticker symbol is required")
+	}
+	ticker := strings.ToUpper(parts[3])
+
+	log.Printf("User %s (%s) requesting financials for %s", authCtx.Username, authCtx.UserID, ticker)
+	return handleStockFinancials(request)
+}
+
+// handleStockFinancials returns annual or quarterly financial statement history
+// GET /api/stocks/{ticker}/financials?period=annual|quarterly&years=N
+func handleStockFinancials(request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Extract ticker from path
+	parts := strings.Split(request.RawPath, "/")
+	if len(parts) < 4 {
+		return errorResponse(400, "Invalid request", "Ticker symbol is required")
+	}
+	ticker := strings.ToUpper(parts[3])
+
+	period := strings.ToLower(request.QueryStringParameters["period"])
+	if period == "" {
+		period = "annual"
+	}
+	if period != "annual" && period != "quarterly" {
+		return errorResponse(400, "Invalid request", "period must be 'annual' or 'quarterly'")
+	}
+
+	// Parse years parameter
+	years := 5 // default
+	if yearsStr := request.QueryStringParameters["years"]; yearsStr != "" {
+		if parsed, err := strconv.Atoi(yearsStr); err == nil && parsed > 0 {
+			years = parsed
+			if years > 20 {
+				years = 20 // EDGAR XBRL data rarely goes back further
+			}
+		}
+	}
+
+	log.Printf("Fetching %s financials for ticker: %s (%d years)", period, ticker, years)
+
+	// Get company data
+	service := NewStockService()
+	companyData, warnings := service.GetCompanyData(ticker)
+
+	var statements []finance.FinancialStatement
+	if companyData.HistoricalData != nil {
+		if period == "annual" {
+			statements = lastStatements(companyData.HistoricalData.AnnualStatements, years)
+		} else {
+			statements = lastStatements(companyData.HistoricalData.QuarterlyStatements, years*4)
+		}
+	}
+
+	if statements == nil {
+		statements = []finance.FinancialStatement{}
+	}
+
+	response := finance.FinancialHistoryResponse{
+		Ticker:      ticker,
+		CompanyName: companyData.CompanyName,
+		Period:      period,
+		Statements:  statements,
+		LastUpdated: time.Now(),
+		Warnings:    warnings,
+	}
+
+	return jsonResponse(200, response)
+}
+
+// handleStockPricesAuth is the authenticated version of handleStockPrices
+func handleStockPricesAuth(request events.APIGatewayV2HTTPRequest, authCtx *auth.AuthContext) (events.APIGatewayV2HTTPResponse, error) {
+	// Extract ticker from path
+	parts := strings.Split(request.RawPath, "/")
+	if len(parts) < 4 {
+		return errorResponse(400, "Invalid request", "Ticker symbol is required")
+	}
+	ticker := strings.ToUpper(parts[3])
+
+	log.Printf("User %s (%s) requesting prices for %s", authCtx.Username, authCtx.UserID, ticker)
+	return handleStockPrices(request)
+}
+
+// handleStockPrices returns historical OHLCV candles
+// GET /api/stocks/{ticker}/prices?from=YYYY-MM-DD&to=YYYY-MM-DD&resolution=D|W|M
+func handleStockPrices(request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Extract ticker from path
+	parts := strings.Split(request.RawPath, "/")
+	if len(parts) < 4 {
+		return errorResponse(400, "Invalid request", "Ticker symbol is required")
+	}
+	ticker := strings.ToUpper(parts[3])
+
+	resolution, from, to, err := parsePriceRange(request.QueryStringParameters)
+	if err != nil {
+		return errorResponse(400, "Invalid request", err.Error())
+	}
+
+	log.Printf("Fetching %s prices for ticker: %s (%s to %s)", resolution, ticker, from.Format("2006-01-02"), to.Format("2006-01-02"))
+
+	service := NewStockService()
+	warnings := []string{}
+	candles, err := service.GetPriceHistory(ticker, resolution, from, to)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Price history unavailable: %v", err))
+		log.Printf("Finnhub candle error for %s: %v", ticker, err)
+		candles = []finance.PriceCandle{}
+	}
+
+	response := finance.PriceHistoryResponse{
+		Ticker:      ticker,
+		Resolution:  resolution,
+		From:        from.Format("2006-01-02"),
+		To:          to.Format("2006-01-02"),
+		Candles:     candles,
+		LastUpdated: time.Now(),
+		Warnings:    warnings,
+	}
+
+	return jsonResponse(200, response)
+}
+
+// handleStockTechnicalsAuth is the authenticated version of handleStockTechnicals
+func handleStockTechnicalsAuth(request events.APIGatewayV2HTTPRequest, authCtx *auth.AuthContext) (events.APIGatewayV2HTTPResponse, error) {
+	// Extract ticker from path
+	parts := strings.Split(request.RawPath, "/")
+	if len(parts) < 4 {
+		return errorResponse(400, "Invalid request", "Ticker symbol is required")
+	}
+	ticker := strings.ToUpper(parts[3])
+
+	log.Printf("User %s (%s) requesting technicals for %s", authCtx.Username, authCtx.UserID, ticker)
+	return handleStockTechnicals(request)
+}
+
+// handleStockTechnicals returns technical indicators computed from daily candles
+// GET /api/stocks/{ticker}/technicals
+func handleStockTechnicals(request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Extract ticker from path
+	parts := strings.Split(request.RawPath, "/")
+	if len(parts) < 4 {
+		return errorResponse(400, "Invalid request", "Ticker symbol is required")
+	}
+	ticker := strings.ToUpper(parts[3])
+
+	log.Printf("Calculating technicals for ticker: %s", ticker)
+
+	to := time.Now().UTC()
+	from := to.AddDate(0, 0, -technicalsLookbackDays)
+
+	service := NewStockService()
+	response := finance.TechnicalAnalysisResponse{
+		Ticker:      ticker,
+		LastUpdated: time.Now(),
+		Warnings:    []string{},
+	}
+
+	candles, err := service.GetPriceHistory(ticker, finance.ResolutionDaily, from, to)
+	if err != nil {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("Price history unavailable: %v", err))
+		log.Printf("Finnhub candle error for %s: %v", ticker, err)
+		return jsonResponse(200, response)
+	}
+	if len(candles) == 0 {
+		response.Warnings = append(response.Warnings, "Price history unavailable: no candles returned")
+		return jsonResponse(200, response)
+	}
+
+	latest := candles[len(candles)-1]
+	response.AsOf = latest.Date.Format("2006-01-02")
+	response.LastClose = latest.Close
+	response.Indicators = technicals.Analyze(candles)
+	response.Summary = technicals.Summarize(response.Indicators)
+
+	return jsonResponse(200, response)
+}
+
+// parsePriceRange reads resolution (default daily) and the from/to dates
+// (default the year to today); to is capped at today
+func parsePriceRange(params map[string]string) (string, time.Time, time.Time, error) {
+	resolution := finance.ResolutionDaily
+	if val := params["resolution"]; val != "" {
+		r, ok := priceResolutions[strings.ToLower(val)]
+		if !ok {
+			return "", time.Time{}, time.Time{}, fmt.Errorf("resolution must be 'D', 'W' or 'M' (daily, weekly or monthly)")
+		}
+		resolution = r
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	to := today
+	if val := params["to"]; val != "" {
+		parsed, err := time.Parse("2006-01-02", val)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, fmt.Errorf("to must be a date in YYYY-MM-DD format")
+		}
+		to = parsed
+	}
+	if to.After(today) {
+		to = today
+	}
+
+	from := to.AddDate(-1, 0, 0)
+	if val := params["from"]; val != "" {
+		parsed, err := time.Parse("2006-01-02", val)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, fmt.Errorf("from must be a date in YYYY-MM-DD format")
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		return "", time.Time{}, time.Time{}, fmt.Errorf("from must be on or before to")
+	}
+
+	return resolution, from, to, nil
+}
+
+// lastStatements returns the most recent n statements of an oldest-first series
+func lastStatements(statements []finance.FinancialStatement, n int) []finance.FinancialStatement {
+	if len(statements) <= n {
+		return statements
+	}
+	return statements[len(statements)-n:]
+}
+
 // parseDCFInput extracts DCF parameters from query string
 func parseDCFInput(params map[string]string) *calculator.DCFInput {
 	input := &calculator.DCFInput{}
@@ -285,9 +1029,134 @@ func parseDCFInput(params map[string]string) *calculator.DCFInput {
 		}
 	}
 
+	if val, ok := params["dcf_mode"]; ok {
+		input.Mode = strings.ToLower(val)
+	}
+
+	if val, ok := params["high_growth_years"]; ok {
+		if n, err := strconv.Atoi(val); err == nil {
+			input.HighGrowthYears = &n
+		}
+	}
+
+	if val, ok := params["fade_years"]; ok {
+		if n, err := strconv.Atoi(val); err == nil {
+			input.FadeYears = &n
+		}
+	}
+
+	if val, ok := params["terminal_profit_margin"]; ok {
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			input.TerminalProfitMargin = &f
+		}
+	}
+
+	if val, ok := params["terminal_fcf_margin"]; ok {
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			input.TerminalFCFMargin = &f
+		}
+	}
+
+	if val, ok := params["risk_free_rate"]; ok {
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			input.RiskFreeRate = &f
+		}
+	}
+
+	if val, ok := params["equity_risk_premium"]; ok {
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			input.EquityRiskPremium = &f
+		}
+	}
+
 	return input
 }
 
+// parseDDMInput maps the shared valuation parameters onto DDM inputs:
+// discount_rate is the cost of equity and high-stage growth comes from dividend_growth
+func parseDDMInput(params map[string]string, dcfInput *calculator.DCFInput) *calculator.DDMInput {
+	input := &calculator.DDMInput{
+		Mode:               strings.ToLower(params["ddm_mode"]),
+		CostOfEquity:       dcfInput.DiscountRate,
+		HighGrowthYears:    dcfInput.HighGrowthYears,
+		TerminalGrowthRate: dcfInput.TerminalGrowthRate,
+		RiskFreeRate:       dcfInput.RiskFreeRate,
+		EquityRiskPremium:  dcfInput.EquityRiskPremium,
+	}
+
+	if val, ok := params["dividend_growth"]; ok {
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			input.DividendGrowthRate = &f
+		}
+	}
+
+	return input
+}
+
+// parseResidualIncomeInput maps the shared valuation parameters onto residual income inputs:
+// discount_rate is the cost of equity and fade_years is how long ROE takes to reach it
+func parseResidualIncomeInput(params map[string]string, dcfInput *calculator.DCFInput) *calculator.ResidualIncomeInput {
+	input := &calculator.ResidualIncomeInput{
+		CostOfEquity:      dcfInput.DiscountRate,
+		FadeYears:         dcfInput.FadeYears,
+		RiskFreeRate:      dcfInput.RiskFreeRate,
+		EquityRiskPremium: dcfInput.EquityRiskPremium,
+	}
+
+	if val, ok := params["roe"]; ok {
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			input.ROE = &f
+		}
+	}
+
+	return input
+}
+
+// parseMonteCarloInput reads simulations, seed and {assumption}_dist parameters
+func parseMonteCarloInput(params map[string]string) (calculator.MonteCarloInput, error) {
+	mc := calculator.MonteCarloInput{
+		Distributions: make(map[string]finance.Distribution),
+		Simulations:   calculator.DefaultSimulations,
+		Seed:          calculator.DefaultSeed,
+	}
+
+	if val, ok := params["simulations"]; ok {
+		n, err := strconv.Atoi(val)
+		if err != nil || n <= 0 || n > calculator.MaxSimulations {
+			return mc, fmt.Errorf("simulations must be between 1 and %d", calculator.MaxSimulations)
+		}
+		mc.Simulations = n
+	}
+
+	if val, ok := params["seed"]; ok {
+		seed, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return mc, fmt.Errorf("seed must be an integer")
+		}
+		mc.Seed = seed
+	}
+
+	assumptions := []string{
+		calculator.SensitivityRevenueGrowth,
+		calculator.SensitivityFCFMargin,
+		calculator.SensitivityDiscountRate,
+		calculator.SensitivityTerminalGrowth,
+	}
+	for _, assumption := range assumptions {
+		spec, ok := params[assumption+"_dist"]
+		if !ok {
+			continue
+		}
+		dist, err := calculator.ParseDistribution(spec)
+		if err != nil {
+			return mc, fmt.Errorf("%s_dist: %v", assumption, err)
+		}
+		mc.Distributions[assumption] = dist
+	}
+
+	return mc, nil
+}
+
 // buildDataFreshness creates a map of data source freshness
 func buildDataFreshness(data *finance.CompanyData) map[string]string {
 	freshness := make(map[string]string)
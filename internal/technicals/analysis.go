@@ -0,0 +1,371 @@
+package technicals
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sshetty/finEdSkywalker/internal/finance"
+)
+
+// Indicator settings (the conventional defaults)
+const (
+	shortSMAPeriod  = 50
+	longSMAPeriod   = 200
+	emaPeriod       = 20
+	rsiPeriod       = 14
+	macdFast        = 12
+	macdSlow        = 26
+	macdSignal      = 9
+	bollingerPeriod = 20
+	bollingerStdDev = 2.0
+	shortVolumeDays = 20
+	longVolumeDays  = 50
+)
+
+// Interpretation thresholds
+const (
+	tradingDaysPerYear = 252
+
+	rsiOverbought       = 70.0
+	rsiOversold         = 30.0
+	nearExtremePercent  = 5.0 // Within 5% of a 52-week high/low counts as "near" it
+	highRelativeVolume  = 1.5
+	lowRelativeVolume   = 0.5
+	minRangeTradingDays = 20
+)
+
+// Analyze computes every indicator from ascending daily candles. Indicators
+// without enough history are marked unavailable.
+func Analyze(candles []finance.PriceCandle) *finance.TechnicalIndicators {
+	closes := make([]float64, len(candles))
+	volumes := make([]float64, len(candles))
+	for i, candle := range candles {
+		closes[i] = candle.Close
+		volumes[i] = candle.Volume
+	}
+
+	return &finance.TechnicalIndicators{
+		SMA50:          analyzeShortSMA(closes),
+		SMA200:         analyzeLongSMA(closes),
+		EMA20:          analyzeEMA(closes),
+		RSI:            analyzeRSI(closes),
+		MACD:           analyzeMACD(closes),
+		BollingerBands: analyzeBollinger(closes),
+		Week52Range:    analyze52WeekRange(candles),
+		AverageVolume:  analyzeVolume(closes, volumes),
+	}
+}
+
+// Summarize tallies the indicators' signals into one line
+func Summarize(indicators *finance.TechnicalIndicators) string {
+	all := []finance.TechnicalIndicator{
+		indicators.SMA50, indicators.SMA200, indicators.EMA20, indicators.RSI,
+		indicators.MACD, indicators.BollingerBands, indicators.Week52Range, indicators.AverageVolume,
+	}
+
+	counts := make(map[finance.TechnicalSignal]int)
+	available := 0
+	for _, indicator := range all {
+		if indicator.Available {
+			counts[indicator.Signal]++
+			available++
+		}
+	}
+	if available == 0 {
+		return "Not enough price history for technical indicators"
+	}
+
+	bullish := counts[finance.SignalBullish]
+	bearish := counts[finance.SignalBearish]
+	tone := "Mixed"
+	switch {
+	case bullish > bearish*2:
+		tone = "Mostly bullish"
+	case bearish > bullish*2:
+		tone = "Mostly bearish"
+	}
+
+	summary := fmt.Sprintf("%s: %d bullish, %d bearish, %d neutral of %d indicators",
+		tone, bullish, bearish, counts[finance.SignalNeutral], available)
+	if counts[finance.SignalOverbought] > 0 {
+		summary += fmt.Sprintf(", %d overbought", counts[finance.SignalOverbought])
+	}
+	if counts[finance.SignalOversold] > 0 {
+		summary += fmt.Sprintf(", %d oversold", counts[finance.SignalOversold])
+	}
+	return summary + ". Technicals describe price behaviour, not what the business is worth"
+}
+
+func unavailable(name string, needed int) finance.TechnicalIndicator {
+	return finance.TechnicalIndicator{
+		Signal:  finance.SignalNA,
+		Message: fmt.Sprintf("%s needs at least %d trading days of history", name, needed),
+	}
+}
+
+// analyzeShortSMA compares the price with its 50-day average
+func analyzeShortSMA(closes []float64) finance.TechnicalIndicator {
+	sma := SMA(closes, shortSMAPeriod)
+	if sma == nil {
+		return unavailable("The 50-day moving average", shortSMAPeriod)
+	}
+
+	price, average := last(closes), last(sma)
+	return priceVsAverage(price, average, "50-day moving average", "a medium-term uptrend", "a medium-term downtrend")
+}
+
+// analyzeLongSMA compares the price with its 200-day average and notes the 50/200 crossover
+func analyzeLongSMA(closes []float64) finance.TechnicalIndicator {
+	long := SMA(closes, longSMAPeriod)
+	if long == nil {
+		return unavailable("The 200-day moving average", longSMAPeriod)
+	}
+
+	price, average := last(closes), last(long)
+	indicator := priceVsAverage(price, average, "200-day moving average", "a long-term uptrend", "a long-term downtrend")
+
+	short := SMA(closes, shortSMAPeriod)
+	indicator.Components["sma_50"] = last(short)
+	if len(long) >= 2 {
+		prevShort, prevLong := short[len(short)-2], long[len(long)-2]
+		switch {
+		case last(short) > average && prevShort <= prevLong:
+			indicator.Message += ". The 50-day just crossed above the 200-day (a \"golden cross\")"
+		case last(short) < average && prevShort >= prevLong:
+			indicator.Message += ". The 50-day just crossed below the 200-day (a \"death cross\")"
+		case last(short) > average:
+			indicator.Message += ". The 50-day is above the 200-day, confirming the longer trend is up"
+		default:
+			indicator.Message += ". The 50-day is below the 200-day, confirming the longer trend is down"
+		}
+	}
+	return indicator
+}
+
+// analyzeEMA compares the price with its 20-day exponential average
+func analyzeEMA(closes []float64) finance.TechnicalIndicator {
+	ema := EMA(closes, emaPeriod)
+	if ema == nil {
+		return unavailable("The 20-day EMA", emaPeriod)
+	}
+
+	indicator := priceVsAverage(last(closes), last(ema), "20-day exponential moving average",
+		"short-term momentum is positive", "short-term momentum is negative")
+	indicator.Message += ". The EMA weights recent days more heavily, so it reacts faster than a simple average"
+	return indicator
+}
+
+// priceVsAverage rates a price above its moving average as bullish and below as bearish
+func priceVsAverage(price, average float64, name, upMeaning, downMeaning string) finance.TechnicalIndicator {
+	distance := (price - average) / average * 100
+	indicator := finance.TechnicalIndicator{
+		Value:      average,
+		Components: map[string]float64{"price_vs_average_percent": distance},
+		Available:  true,
+	}
+
+	if price >= average {
+		indicator.Signal = finance.SignalBullish
+		indicator.Message = fmt.Sprintf("Price ($%.2f) is %.1f%% above its %s ($%.2f): %s",
+			price, distance, name, average, upMeaning)
+	} else {
+		indicator.Signal = finance.SignalBearish
+		indicator.Message = fmt.Sprintf("Price ($%.2f) is %.1f%% below its %s ($%.2f): %s",
+			price, -distance, name, average, downMeaning)
+	}
+	return indicator
+}
+
+// analyzeRSI flags overbought (70+) and oversold (30-) momentum
+func analyzeRSI(closes []float64) finance.TechnicalIndicator {
+	rsi := RSI(closes, rsiPeriod)
+	if rsi == nil {
+		return unavailable("RSI", rsiPeriod+1)
+	}
+
+	value := last(rsi)
+	indicator := finance.TechnicalIndicator{Value: value, Available: true}
+	switch {
+	case value >= rsiOverbought:
+		indicator.Signal = finance.SignalOverbought
+		indicator.Message = fmt.Sprintf("RSI of %.1f is above %.0f: the stock has risen quickly and may be overbought; pullbacks are common from here", value, rsiOverbought)
+	case value <= rsiOversold:
+		indicator.Signal = finance.SignalOversold
+		indicator.Message = fmt.Sprintf("RSI of %.1f is below %.0f: the stock has fallen quickly and may be oversold; bounces are common from here", value, rsiOversold)
+	default:
+		indicator.Signal = finance.SignalNeutral
+		indicator.Message = fmt.Sprintf("RSI of %.1f is between %.0f and %.0f: momentum is not stretched in either direction", value, rsiOversold, rsiOverbought)
+	}
+	return indicator
+}
+
+// analyzeMACD reads momentum from the MACD line's position against its signal line
+func analyzeMACD(closes []float64) finance.TechnicalIndicator {
+	line, signal, histogram := MACD(closes, macdFast, macdSlow, macdSignal)
+	if line == nil {
+		return unavailable("MACD", macdSlow+macdSignal-1)
+	}
+
+	value, signalValue, hist := last(line), last(signal), last(histogram)
+	indicator := finance.TechnicalIndicator{
+		Value: value,
+		Components: map[string]float64{
+			"macd":      value,
+			"signal":    signalValue,
+			"histogram": hist,
+		},
+		Available: true,
+	}
+
+	crossed := len(histogram) >= 2 && (histogram[len(histogram)-2] > 0) != (hist > 0)
+	if hist > 0 {
+		indicator.Signal = finance.SignalBullish
+		indicator.Message = fmt.Sprintf("MACD (%.2f) is above its signal line (%.2f): upward momentum is building", value, signalValue)
+		if crossed {
+			indicator.Message += ". It crossed above the signal line in the latest session, a classic buy signal"
+		}
+	} else {
+		indicator.Signal = finance.SignalBearish
+		indicator.Message = fmt.Sprintf("MACD (%.2f) is below its signal line (%.2f): momentum is fading", value, signalValue)
+		if crossed {
+			indicator.Message += ". It crossed below the signal line in the latest session, a classic sell signal"
+		}
+	}
+	return indicator
+}
+
+// analyzeBollinger locates the price within its 20-day, 2 standard deviation bands
+func analyzeBollinger(closes []float64) finance.TechnicalIndicator {
+	upper, middle, lower := BollingerBands(closes, bollingerPeriod, bollingerStdDev)
+	if middle == nil {
+		return unavailable("Bollinger Bands", bollingerPeriod)
+	}
+
+	price := last(closes)
+	up, mid, low := last(upper), last(middle), last(lower)
+
+	// %B: 0 at the lower band, 1 at the upper band
+	percentB := 0.5
+	if up > low {
+		percentB = (price - low) / (up - low)
+	}
+
+	indicator := finance.TechnicalIndicator{
+		Value: percentB,
+		Components: map[string]float64{
+			"upper":             up,
+			"middle":            mid,
+			"lower":             low,
+			"bandwidth_percent": (up - low) / mid * 100,
+		},
+		Available: true,
+	}
+
+	switch {
+	case price > up:
+		indicator.Signal = finance.SignalOverbought
+		indicator.Message = fmt.Sprintf("Price ($%.2f) closed above the upper band ($%.2f), more than 2 standard deviations above its 20-day average: an unusually strong move that often cools off", price, up)
+	case price < low:
+		indicator.Signal = finance.SignalOversold
+		indicator.Message = fmt.Sprintf("Price ($%.2f) closed below the lower band ($%.2f), more than 2 standard deviations below its 20-day average: an unusually weak move that often snaps back", price, low)
+	default:
+		indicator.Signal = finance.SignalNeutral
+		indicator.Message = fmt.Sprintf("Price ($%.2f) is inside the bands ($%.2f-$%.2f) at %%B %.2f, where 0 is the lower band and 1 the upper band", price, low, up, percentB)
+	}
+	return indicator
+}
+
+// analyze52WeekRange measures the distance from the 52-week high and low
+func analyze52WeekRange(candles []finance.PriceCandle) finance.TechnicalIndicator {
+	if len(candles) < minRangeTradingDays {
+		return unavailable("The 52-week range", minRangeTradingDays)
+	}
+
+	window := candles
+	if len(window) > tradingDaysPerYear {
+		window = window[len(window)-tradingDaysPerYear:]
+	}
+
+	high, low := window[0].High, window[0].Low
+	for _, candle := range window {
+		high = math.Max(high, candle.High)
+		low = math.Min(low, candle.Low)
+	}
+
+	price := candles[len(candles)-1].Close
+	fromHigh := (price - high) / high * 100
+	fromLow := (price - low) / low * 100
+
+	indicator := finance.TechnicalIndicator{
+		Value: fromHigh,
+		Components: map[string]float64{
+			"high":              high,
+			"low":               low,
+			"percent_from_high": fromHigh,
+			"percent_from_low":  fromLow,
+		},
+		Available: true,
+	}
+
+	period := "52-week"
+	if len(window) < tradingDaysPerYear {
+		period = fmt.Sprintf("%d-day", len(window))
+	}
+
+	switch {
+	case -fromHigh <= nearExtremePercent:
+		indicator.Signal = finance.SignalBullish
+		indicator.Message = fmt.Sprintf("Price is %.1f%% below its %s high ($%.2f): trading near the top of its range shows strong relative strength", -fromHigh, period, high)
+	case fromLow <= nearExtremePercent:
+		indicator.Signal = finance.SignalBearish
+		indicator.Message = fmt.Sprintf("Price is %.1f%% above its %s low ($%.2f): trading near the bottom of its range shows persistent selling", fromLow, period, low)
+	default:
+		indicator.Signal = finance.SignalNeutral
+		indicator.Message = fmt.Sprintf("Price is %.1f%% below its %s high ($%.2f) and %.1f%% above its low ($%.2f)", -fromHigh, period, high, fromLow, low)
+	}
+	return indicator
+}
+
+// analyzeVolume compares the latest session's volume with the 50-day average
+func analyzeVolume(closes, volumes []float64) finance.TechnicalIndicator {
+	long := SMA(volumes, longVolumeDays)
+	if long == nil || last(long) <= 0 || len(closes) < 2 {
+		return unavailable("Average volume", longVolumeDays)
+	}
+
+	average := last(long)
+	latest := last(volumes)
+	relative := latest / average
+
+	indicator := finance.TechnicalIndicator{
+		Value: average,
+		Components: map[string]float64{
+			"average_20":      last(SMA(volumes, shortVolumeDays)),
+			"average_50":      average,
+			"latest":          latest,
+			"relative_volume": relative,
+		},
+		Available: true,
+	}
+
+	up := closes[len(closes)-1] >= closes[len(closes)-2]
+	switch {
+	case relative >= highRelativeVolume && up:
+		indicator.Signal = finance.SignalBullish
+		indicator.Message = fmt.Sprintf("The latest session traded %.1fx the 50-day average volume on a rising price: heavy buying confirms the move", relative)
+	case relative >= highRelativeVolume:
+		indicator.Signal = finance.SignalBearish
+		indicator.Message = fmt.Sprintf("The latest session traded %.1fx the 50-day average volume on a falling price: heavy selling confirms the move", relative)
+	case relative <= lowRelativeVolume:
+		indicator.Signal = finance.SignalNeutral
+		indicator.Message = fmt.Sprintf("The latest session traded only %.1fx the 50-day average volume: light trading means the day's move carries little conviction", relative)
+	default:
+		indicator.Signal = finance.SignalNeutral
+		indicator.Message = fmt.Sprintf("The latest session traded %.1fx the 50-day average volume: ordinary participation", relative)
+	}
+	return indicator
+}
+
+func last(values []float64) float64 {
+	return values[len(values)-1]
+}
@@ -0,0 +1,145 @@
+package technicals
+
+import "math"
+
+// Series functions return one value per complete window, aligned with the end
+// of the input: the last element is the latest reading. They return nil when
+// there are too few values.
+
+// SMA is the simple moving average over period values
+func SMA(values []float64, period int) []float64 {
+	if period <= 0 || len(values) < period {
+		return nil
+	}
+
+	out := make([]float64, 0, len(values)-period+1)
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		if i >= period {
+			sum -= values[i-period]
+		}
+		if i >= period-1 {
+			out = append(out, sum/float64(period))
+		}
+	}
+	return out
+}
+
+// EMA is the exponential moving average, seeded with the SMA of the first period values
+func EMA(values []float64, period int) []float64 {
+	if period <= 0 || len(values) < period {
+		return nil
+	}
+
+	k := 2 / float64(period+1)
+	out := make([]float64, 0, len(values)-period+1)
+
+	seed := 0.0
+	for _, v := range values[:period] {
+		seed += v
+	}
+	out = append(out, seed/float64(period))
+
+	for _, v := range values[period:] {
+		prev := out[len(out)-1]
+		out = append(out, prev+k*(v-prev))
+	}
+	return out
+}
+
+// RSI is Wilder's relative strength index (0-100) over period price changes
+func RSI(values []float64, period int) []float64 {
+	if period <= 0 || len(values) < period+1 {
+		return nil
+	}
+
+	avgGain, avgLoss := 0.0, 0.0
+	for i := 1; i <= period; i++ {
+		change := values[i] - values[i-1]
+		if change > 0 {
+			avgGain += change
+		} else {
+			avgLoss -= change
+		}
+	}
+	avgGain /= float64(period)
+	avgLoss /= float64(period)
+
+	out := make([]float64, 0, len(values)-period)
+	out = append(out, rsiValue(avgGain, avgLoss))
+
+	// Wilder smoothing: each new change carries 1/period of the weight
+	for i := period + 1; i < len(values); i++ {
+		gain, loss := 0.0, 0.0
+		if change := values[i] - values[i-1]; change > 0 {
+			gain = change
+		} else {
+			loss = -change
+		}
+		avgGain = (avgGain*float64(period-1) + gain) / float64(period)
+		avgLoss = (avgLoss*float64(period-1) + loss) / float64(period)
+		out = append(out, rsiValue(avgGain, avgLoss))
+	}
+	return out
+}
+
+func rsiValue(avgGain, avgLoss float64) float64 {
+	if avgLoss == 0 {
+		if avgGain == 0 {
+			return 50
+		}
+		return 100
+	}
+	return 100 - 100/(1+avgGain/avgLoss)
+}
+
+// MACD returns the MACD line (fast EMA - slow EMA), its signal-period EMA and
+// the histogram (MACD - signal), all trimmed to the signal line's length
+func MACD(values []float64, fast, slow, signal int) (macdLine, signalLine, histogram []float64) {
+	if fast <= 0 || fast >= slow || len(values) < slow+signal-1 {
+		return nil, nil, nil
+	}
+
+	fastEMA := EMA(values, fast)
+	slowEMA := EMA(values, slow)
+	offset := len(fastEMA) - len(slowEMA)
+
+	line := make([]float64, len(slowEMA))
+	for i := range slowEMA {
+		line[i] = fastEMA[i+offset] - slowEMA[i]
+	}
+
+	signalLine = EMA(line, signal)
+	if signalLine == nil {
+		return nil, nil, nil
+	}
+
+	macdLine = line[len(line)-len(signalLine):]
+	histogram = make([]float64, len(signalLine))
+	for i := range signalLine {
+		histogram[i] = macdLine[i] - signalLine[i]
+	}
+	return macdLine, signalLine, histogram
+}
+
+// BollingerBands returns the period SMA and bands stdDevs population standard deviations above and below it
+func BollingerBands(values []float64, period int, stdDevs float64) (upper, middle, lower []float64) {
+	middle = SMA(values, period)
+	if middle == nil {
+		return nil, nil, nil
+	}
+
+	upper = make([]float64, len(middle))
+	lower = make([]float64, len(middle))
+	for i, mean := range middle {
+		variance := 0.0
+		for _, v := range values[i : i+period] {
+			variance += (v - mean) * (v - mean)
+		}
+		width := stdDevs * math.Sqrt(variance/float64(period))
+		upper[i] = mean + width
+		lower[i] = mean - width
+	}
+	return upper, middle, lower
+}
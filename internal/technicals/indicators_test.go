@@ -0,0 +1,199 @@
+package technicals
+
+import (
+	"math"
+	"testing"
+)
+
+// stockChartsEMACloses is the 10-day moving average example from StockCharts ChartSchool
+var stockChartsEMACloses = []float64{
+	22.2734, 22.1940, 22.0847, 22.1741, 22.1840, 22.1344, 22.2337, 22.4323, 22.2436, 22.2933,
+	22.1542, 22.3926, 22.3816, 22.6109, 23.3558, 24.0519, 23.7530, 23.8324, 23.9516, 23.6338,
+	23.8225, 23.8722, 23.6537, 23.1870, 23.0976, 23.3260, 22.6805, 23.0976, 22.4025, 22.1725,
+}
+
+// wilderRSICloses is the 14-day RSI example from StockCharts ChartSchool
+var wilderRSICloses = []float64{
+	44.3389, 44.0902, 44.1497, 43.6124, 44.3278, 44.8264, 45.0955, 45.4245, 45.8433, 46.0826,
+	45.8931, 46.0328, 45.6140, 46.2820, 46.2820, 46.0028, 46.0328, 46.4116, 46.2222, 45.6439,
+	46.2122, 46.2521, 45.7137, 46.4515, 45.7835, 45.3548, 44.0288, 44.1783, 44.2181, 44.5672,
+	43.4205, 42.6628, 43.1314,
+}
+
+// assertSeries compares a series against reference values published to two decimals
+func assertSeries(t *testing.T, name string, got, want []float64, tolerance float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d values, want %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > tolerance {
+			t.Errorf("%s[%d] = %.4f, want %.2f", name, i, got[i], want[i])
+		}
+	}
+}
+
+func TestSMA(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		period int
+		want   []float64
+	}{
+		{
+			name:   "stockcharts 10-day",
+			values: stockChartsEMACloses,
+			period: 10,
+			want: []float64{
+				22.22, 22.21, 22.23, 22.26, 22.31, 22.42, 22.61, 22.77, 22.91, 23.08, 23.21,
+				23.38, 23.53, 23.65, 23.71, 23.69, 23.61, 23.51, 23.43, 23.28, 23.13,
+			},
+		},
+		{name: "window equals length", values: []float64{1, 2, 3}, period: 3, want: []float64{2}},
+		{name: "too few values", values: []float64{1, 2}, period: 3, want: nil},
+		{name: "invalid period", values: []float64{1, 2}, period: 0, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSeries(t, "SMA", SMA(tt.values, tt.period), tt.want, 0.006)
+		})
+	}
+}
+
+func TestEMA(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		period int
+		want   []float64
+	}{
+		{
+			name:   "stockcharts 10-day",
+			values: stockChartsEMACloses,
+			period: 10,
+			want: []float64{
+				22.22, 22.21, 22.24, 22.27, 22.33, 22.52, 22.80, 22.97, 23.13, 23.28, 23.34,
+				23.43, 23.51, 23.54, 23.47, 23.40, 23.39, 23.26, 23.23, 23.08, 22.92,
+			},
+		},
+		{name: "constant series", values: []float64{5, 5, 5, 5}, period: 2, want: []float64{5, 5, 5}},
+		{name: "too few values", values: []float64{1}, period: 2, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSeries(t, "EMA", EMA(tt.values, tt.period), tt.want, 0.006)
+		})
+	}
+}
+
+func TestRSI(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		period int
+		want   []float64
+	}{
+		{
+			name:   "wilder 14-day",
+			values: wilderRSICloses,
+			period: 14,
+			want: []float64{
+				70.53, 66.32, 66.55, 69.41, 66.36, 57.97, 62.93, 63.26, 56.06, 62.38,
+				54.71, 50.42, 39.99, 41.46, 41.87, 45.46, 37.30, 33.08, 37.77,
+			},
+		},
+		{name: "only gains", values: []float64{1, 2, 3, 4}, period: 3, want: []float64{100}},
+		{name: "only losses", values: []float64{4, 3, 2, 1}, period: 3, want: []float64{0}},
+		{name: "flat", values: []float64{2, 2, 2, 2}, period: 3, want: []float64{50}},
+		{name: "too few values", values: []float64{1, 2, 3}, period: 3, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSeries(t, "RSI", RSI(tt.values, tt.period), tt.want, 0.006)
+		})
+	}
+}
+
+func TestMACD(t *testing.T) {
+	// On a straight line every EMA settles (period-1)/2 behind the price, so the
+	// 12/26 MACD is exactly (26-12)/2 = 7 with a matching signal line
+	ramp := make([]float64, 60)
+	for i := range ramp {
+		ramp[i] = float64(i)
+	}
+
+	tests := []struct {
+		name          string
+		values        []float64
+		wantLen       int
+		wantMACD      float64
+		wantSignal    float64
+		wantHistogram float64
+	}{
+		{name: "linear ramp", values: ramp, wantLen: 60 - 26 - 9 + 2, wantMACD: 7, wantSignal: 7, wantHistogram: 0},
+		{name: "constant series", values: make([]float64, 40), wantLen: 40 - 26 - 9 + 2},
+		{name: "too few values", values: ramp[:33], wantLen: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			macdLine, signalLine, histogram := MACD(tt.values, 12, 26, 9)
+			if len(macdLine) != tt.wantLen || len(signalLine) != tt.wantLen || len(histogram) != tt.wantLen {
+				t.Fatalf("lengths = %d/%d/%d, want %d", len(macdLine), len(signalLine), len(histogram), tt.wantLen)
+			}
+			for i := range macdLine {
+				if math.Abs(macdLine[i]-tt.wantMACD) > 1e-9 ||
+					math.Abs(signalLine[i]-tt.wantSignal) > 1e-9 ||
+					math.Abs(histogram[i]-tt.wantHistogram) > 1e-9 {
+					t.Errorf("[%d] = %.6f/%.6f/%.6f, want %v/%v/%v", i, macdLine[i], signalLine[i], histogram[i],
+						tt.wantMACD, tt.wantSignal, tt.wantHistogram)
+				}
+			}
+		})
+	}
+}
+
+func TestBollingerBands(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    []float64
+		period    int
+		stdDevs   float64
+		wantUpper []float64
+		wantMid   []float64
+		wantLower []float64
+	}{
+		{
+			// Population standard deviation of this series is exactly 2
+			name:      "textbook series",
+			values:    []float64{2, 4, 4, 4, 5, 5, 7, 9},
+			period:    8,
+			stdDevs:   2,
+			wantUpper: []float64{9},
+			wantMid:   []float64{5},
+			wantLower: []float64{1},
+		},
+		{
+			name:      "flat series has no width",
+			values:    []float64{3, 3, 3, 3},
+			period:    2,
+			stdDevs:   2,
+			wantUpper: []float64{3, 3, 3},
+			wantMid:   []float64{3, 3, 3},
+			wantLower: []float64{3, 3, 3},
+		},
+		{name: "too few values", values: []float64{1}, period: 2, stdDevs: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upper, middle, lower := BollingerBands(tt.values, tt.period, tt.stdDevs)
+			assertSeries(t, "upper", upper, tt.wantUpper, 1e-9)
+			assertSeries(t, "middle", middle, tt.wantMid, 1e-9)
+			assertSeries(t, "lower", lower, tt.wantLower, 1e-9)
+		})
+	}
+}